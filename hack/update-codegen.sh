@@ -37,6 +37,7 @@ if [[ -n "${API_KNOWN_VIOLATIONS_DIR:-}" ]]; then
 fi
 
 kube::codegen::gen_openapi \
+    --extra-pkgs k8s.io/apimachinery/pkg/api/resource \
     --output-dir "${SCRIPT_ROOT}/pkg/generated/openapi" \
     --output-pkg "${THIS_PKG}/pkg/generated/openapi" \
     --report-filename "${report_filename:-"/dev/null"}" \
@@ -44,10 +45,53 @@ kube::codegen::gen_openapi \
     --boilerplate "${SCRIPT_ROOT}/hack/boilerplate.go.txt" \
     "${SCRIPT_ROOT}/pkg/apis"
 
+# go-to-protobuf reads and writes packages in a GOPATH-like layout, which is
+# assembled from the module cache in a temporary directory. It also needs
+# protoc and goimports on the PATH.
+PROTO_ROOT="$(mktemp -d)"
+trap 'rm -rf "${PROTO_ROOT}"' EXIT
+
+mkdir -p "${PROTO_ROOT}/src/k8s.io" "${PROTO_ROOT}/src/github.com/gogo"
+ln -s "$(cd "${SCRIPT_ROOT}" && pwd -P)" "${PROTO_ROOT}/src/${THIS_PKG}"
+for mod in k8s.io/api k8s.io/apimachinery; do
+    ln -s "$(cd "${SCRIPT_ROOT}" && go list -m -f '{{.Dir}}' "${mod}")" "${PROTO_ROOT}/src/${mod}"
+done
+ln -s "$(cd "${SCRIPT_ROOT}" && go list -m -f '{{.Dir}}' github.com/gogo/protobuf)" "${PROTO_ROOT}/src/github.com/gogo/protobuf"
+
+(
+    cd "${CODEGEN_PKG}"
+    GO111MODULE=on GOBIN="${PROTO_ROOT}/bin" go install ./cmd/go-to-protobuf ./cmd/go-to-protobuf/protoc-gen-gogo ./cmd/prerelease-lifecycle-gen
+)
+
+# kube_codegen.sh does not generate the API lifecycle methods, which decide
+# the emulated wardle versions serving a type.
+(
+    cd "${SCRIPT_ROOT}"
+    "${PROTO_ROOT}/bin/prerelease-lifecycle-gen" \
+        --output-file zz_generated.prerelease-lifecycle.go \
+        --go-header-file "${SCRIPT_ROOT}/hack/boilerplate.go.txt" \
+        "${THIS_PKG}/pkg/apis/wardle/v1alpha1" \
+        "${THIS_PKG}/pkg/apis/wardle/v1beta1"
+)
+
+(
+    cd "${SCRIPT_ROOT}"
+    PATH="${PROTO_ROOT}/bin:${PATH}" "${PROTO_ROOT}/bin/go-to-protobuf" \
+        --go-header-file "${SCRIPT_ROOT}/hack/boilerplate.go.txt" \
+        --apimachinery-packages "-k8s.io/apimachinery/pkg/util/intstr,-k8s.io/apimachinery/pkg/api/resource,-k8s.io/apimachinery/pkg/runtime/schema,-k8s.io/apimachinery/pkg/runtime,-k8s.io/apimachinery/pkg/apis/meta/v1" \
+        --packages "-k8s.io/api/core/v1,${THIS_PKG}/pkg/apis/wardle/v1alpha1,${THIS_PKG}/pkg/apis/wardle/v1beta1" \
+        --output-dir "${PROTO_ROOT}/src" \
+        --proto-import "${PROTO_ROOT}/src" \
+        --proto-import "${PROTO_ROOT}/src/github.com/gogo/protobuf/protobuf"
+)
+
 kube::codegen::gen_client \
     --with-watch \
     --with-applyconfig \
+    --prefers-protobuf \
     --output-dir "${SCRIPT_ROOT}/pkg/generated" \
     --output-pkg "${THIS_PKG}/pkg/generated" \
     --boilerplate "${SCRIPT_ROOT}/hack/boilerplate.go.txt" \
     "${SCRIPT_ROOT}/pkg/apis"
+
+(cd "${SCRIPT_ROOT}" && go run ./cmd/crd-gen --output-dir artifacts/crds)
@@ -23,17 +23,51 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/sample-apiserver/pkg/admission/wardleinitializer"
 	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/banning"
 	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
 	listers "k8s.io/sample-apiserver/pkg/generated/listers/wardle/v1alpha1"
 )
 
+const (
+	// DecisionAuditAnnotation records whether the name of a Flunder was
+	// allowed or banned.
+	DecisionAuditAnnotation = "banflunder.wardle.example.com/decision"
+	// FischerAuditAnnotation records the Fischer which banned a Flunder.
+	FischerAuditAnnotation = "banflunder.wardle.example.com/fischer"
+	// FlunderPolicyAuditAnnotation records the FlunderPolicy which banned a
+	// Flunder, if it was not banned by a Fischer.
+	FlunderPolicyAuditAnnotation = "banflunder.wardle.example.com/flunderpolicy"
+	// EntryAuditAnnotation records the entry which banned a Flunder.
+	EntryAuditAnnotation = "banflunder.wardle.example.com/entry"
+
+	// DecisionAllowed is the decision for allowed names.
+	DecisionAllowed = "allowed"
+	// DecisionBanned is the decision for banned names.
+	DecisionBanned = "banned"
+)
+
+const (
+	// CauseTypeFischerBan is the type of the status causes of names banned
+	// by a Fischer.
+	CauseTypeFischerBan metav1.CauseType = "FischerBan"
+	// CauseTypeFlunderPolicyBan is the type of the status causes of names
+	// banned by a FlunderPolicy.
+	CauseTypeFlunderPolicyBan metav1.CauseType = "FlunderPolicyBan"
+)
+
+// PluginName is the name of the plugin.
+const PluginName = "BanFlunder"
+
 // Register registers a plugin
 func Register(plugins *admission.Plugins) {
-	plugins.Register("BanFlunder", func(config io.Reader) (admission.Interface, error) {
+	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
 		return New()
 	})
 }
@@ -41,14 +75,20 @@ func Register(plugins *admission.Plugins) {
 // DisallowFlunder is a ban flunder admission plugin
 type DisallowFlunder struct {
 	*admission.Handler
-	lister listers.FischerLister
+	lister       listers.FischerLister
+	policyLister listers.FlunderPolicyLister
+	served       func(obj runtime.Object) bool
 }
 
 var _ = wardleinitializer.WantsInternalWardleInformerFactory(&DisallowFlunder{})
+var _ = wardleinitializer.WantsServedKinds(&DisallowFlunder{})
 
 // Admit ensures that the object in-flight is of kind Flunder.
 // In addition checks that the Name is not on the banned list.
-// The list is stored in Fischers API objects.
+// The list is stored in Fischers API objects, and in FlunderPolicy API objects
+// of the namespace of the Flunder.
+// The decision and the first entry banning the name are recorded in audit
+// annotations, and every ban is returned as a cause of the Forbidden status.
 func (d *DisallowFlunder) Admit(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
 	// we are only interested in flunders
 	if a.GetKind().GroupKind() != wardle.Kind("Flunder") {
@@ -63,32 +103,102 @@ func (d *DisallowFlunder) Admit(ctx context.Context, a admission.Attributes, o a
 	if err != nil {
 		return err
 	}
-	flunderName := metaAccessor.GetName()
 
 	fischers, err := d.lister.List(labels.Everything())
 	if err != nil {
 		return err
 	}
 
-	for _, fischer := range fischers {
-		for _, disallowedFlunder := range fischer.DisallowedFlunders {
-			if flunderName == disallowedFlunder {
-				return errors.NewForbidden(
-					a.GetResource().GroupResource(),
-					a.GetName(),
-					fmt.Errorf("this name may not be used, please change the resource name"),
-				)
-			}
+	var policies []*v1alpha1.FlunderPolicy
+	if d.policyLister != nil {
+		policies, err = d.policyLister.FlunderPolicies(metaAccessor.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			return err
+		}
+	}
+
+	bans := banning.Bans(fischers, policies, banning.Attributes{
+		Name:      metaAccessor.GetName(),
+		Namespace: metaAccessor.GetNamespace(),
+	})
+	if err := addAuditAnnotations(a, bans); err != nil {
+		return err
+	}
+	if len(bans) > 0 {
+		err := errors.NewForbidden(
+			a.GetResource().GroupResource(),
+			a.GetName(),
+			fmt.Errorf("this name may not be used, please change the resource name"),
+		)
+		for _, ban := range bans {
+			err.ErrStatus.Details.Causes = append(err.ErrStatus.Details.Causes, statusCause(ban))
 		}
+		return err
 	}
 	return nil
 }
 
-// SetInternalWardleInformerFactory gets Lister from SharedInformerFactory.
-// The lister knows how to lists Fischers.
+// addAuditAnnotations records the decision and the first ban, if any.
+func addAuditAnnotations(a admission.Attributes, bans []banning.Ban) error {
+	annotations := map[string]string{DecisionAuditAnnotation: DecisionAllowed}
+	if len(bans) > 0 {
+		annotations[DecisionAuditAnnotation] = DecisionBanned
+		annotations[EntryAuditAnnotation] = bans[0].Entry
+		if len(bans[0].FlunderPolicy) != 0 {
+			annotations[FlunderPolicyAuditAnnotation] = bans[0].FlunderPolicy
+		} else {
+			annotations[FischerAuditAnnotation] = bans[0].Fischer
+		}
+	}
+	for key, value := range annotations {
+		if err := a.AddAnnotation(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statusCause describes a ban in the status returned to the client.
+func statusCause(ban banning.Ban) metav1.StatusCause {
+	if len(ban.FlunderPolicy) != 0 {
+		return metav1.StatusCause{
+			Type:    CauseTypeFlunderPolicyBan,
+			Message: fmt.Sprintf("FlunderPolicy %q disallows %q", ban.FlunderPolicy, ban.Entry),
+			Field:   "metadata.name",
+		}
+	}
+	return metav1.StatusCause{
+		Type:    CauseTypeFischerBan,
+		Message: fmt.Sprintf("Fischer %q disallows %q", ban.Fischer, ban.Entry),
+		Field:   "metadata.name",
+	}
+}
+
+// SetServedKinds sets whether the FlunderPolicies are served. If they are
+// not, only the Fischers ban Flunders.
+func (d *DisallowFlunder) SetServedKinds(served func(obj runtime.Object) bool) {
+	d.served = served
+}
+
+// policiesServed returns whether the FlunderPolicies are served.
+func (d *DisallowFlunder) policiesServed() bool {
+	return d.served == nil || d.served(&v1alpha1.FlunderPolicy{})
+}
+
+// SetInternalWardleInformerFactory gets Listers from SharedInformerFactory.
+// The listers know how to lists Fischers and FlunderPolicies.
 func (d *DisallowFlunder) SetInternalWardleInformerFactory(f informers.SharedInformerFactory) {
-	d.lister = f.Wardle().V1alpha1().Fischers().Lister()
-	d.SetReadyFunc(f.Wardle().V1alpha1().Fischers().Informer().HasSynced)
+	fischers := f.Wardle().V1alpha1().Fischers()
+	d.lister = fischers.Lister()
+	if !d.policiesServed() {
+		d.SetReadyFunc(fischers.Informer().HasSynced)
+		return
+	}
+	policies := f.Wardle().V1alpha1().FlunderPolicies()
+	d.policyLister = policies.Lister()
+	d.SetReadyFunc(func() bool {
+		return fischers.Informer().HasSynced() && policies.Informer().HasSynced()
+	})
 }
 
 // ValidateInitialization checks whether the plugin was correctly initialized.
@@ -96,6 +206,9 @@ func (d *DisallowFlunder) ValidateInitialization() error {
 	if d.lister == nil {
 		return fmt.Errorf("missing fischer lister")
 	}
+	if d.policyLister == nil && d.policiesServed() {
+		return fmt.Errorf("missing flunder policy lister")
+	}
 	return nil
 }
 
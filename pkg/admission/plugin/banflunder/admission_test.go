@@ -21,6 +21,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -38,6 +42,7 @@ import (
 func TestBanflunderAdmissionPlugin(t *testing.T) {
 	var scenarios = []struct {
 		informersOutput        wardle.FischerList
+		policiesOutput         wardle.FlunderPolicyList
 		admissionInput         wardle.Flunder
 		admissionInputKind     schema.GroupVersionKind
 		admissionInputResource schema.GroupVersionResource
@@ -98,6 +103,74 @@ func TestBanflunderAdmissionPlugin(t *testing.T) {
 			admissionInputResource: wardle.Resource("notflunders").WithVersion("version"),
 			admissionMustFail:      false,
 		},
+		// scenario 4:
+		// a flunder with a name that a policy of its namespace disallows must be banned
+		{
+			policiesOutput: wardle.FlunderPolicyList{
+				Items: []wardle.FlunderPolicy{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "team"},
+						Spec:       wardle.FlunderPolicySpec{DisallowedFlunders: []string{"badname"}},
+					},
+				},
+			},
+			admissionInput: wardle.Flunder{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "badname",
+					Namespace: "team",
+				},
+			},
+			admissionInputKind:     wardle.SchemeGroupVersion.WithKind("Flunder").GroupKind().WithVersion("version"),
+			admissionInputResource: wardle.Resource("flunders").WithVersion("version"),
+			admissionMustFail:      true,
+		},
+		// scenario 5:
+		// a flunder with a name that a policy of another namespace disallows must be admitted
+		{
+			policiesOutput: wardle.FlunderPolicyList{
+				Items: []wardle.FlunderPolicy{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "other"},
+						Spec:       wardle.FlunderPolicySpec{DisallowedFlunders: []string{"badname"}},
+					},
+				},
+			},
+			admissionInput: wardle.Flunder{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "badname",
+					Namespace: "team",
+				},
+			},
+			admissionInputKind:     wardle.SchemeGroupVersion.WithKind("Flunder").GroupKind().WithVersion("version"),
+			admissionInputResource: wardle.Resource("flunders").WithVersion("version"),
+			admissionMustFail:      false,
+		},
+		// scenario 6:
+		// a policy cannot allow a name that a fischer disallows
+		{
+			informersOutput: wardle.FischerList{
+				Items: []wardle.Fischer{
+					{DisallowedFlunders: []string{"badname"}},
+				},
+			},
+			policiesOutput: wardle.FlunderPolicyList{
+				Items: []wardle.FlunderPolicy{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "team"},
+						Spec:       wardle.FlunderPolicySpec{AllowedFlunders: []string{"badname"}},
+					},
+				},
+			},
+			admissionInput: wardle.Flunder{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "badname",
+					Namespace: "team",
+				},
+			},
+			admissionInputKind:     wardle.SchemeGroupVersion.WithKind("Flunder").GroupKind().WithVersion("version"),
+			admissionInputResource: wardle.Resource("flunders").WithVersion("version"),
+			admissionMustFail:      true,
+		},
 	}
 
 	for index, scenario := range scenarios {
@@ -107,6 +180,9 @@ func TestBanflunderAdmissionPlugin(t *testing.T) {
 			cs.AddReactor("list", "fischers", func(action clienttesting.Action) (bool, runtime.Object, error) {
 				return true, &scenario.informersOutput, nil
 			})
+			cs.AddReactor("list", "flunderpolicies", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				return true, &scenario.policiesOutput, nil
+			})
 			informersFactory := informers.NewSharedInformerFactory(cs, 5*time.Minute)
 
 			target, err := banflunder.New()
@@ -114,7 +190,7 @@ func TestBanflunderAdmissionPlugin(t *testing.T) {
 				t.Fatalf("scenario %d: failed to create banflunder admission plugin due to = %v", index, err)
 			}
 
-			targetInitializer := wardleinitializer.New(informersFactory)
+			targetInitializer := wardleinitializer.New(informersFactory, cs)
 			targetInitializer.Initialize(target)
 
 			err = admission.ValidateInitialization(target)
@@ -154,3 +230,123 @@ func TestBanflunderAdmissionPlugin(t *testing.T) {
 		}()
 	}
 }
+
+// annotatedAttributes records the audit annotations added by the plugin.
+type annotatedAttributes struct {
+	admission.Attributes
+	annotations map[string]string
+}
+
+func (a *annotatedAttributes) AddAnnotation(key, value string) error {
+	a.annotations[key] = value
+	return a.Attributes.AddAnnotation(key, value)
+}
+
+func TestBanflunderAuditAnnotationsAndCauses(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		name                string
+		expectedAnnotations map[string]string
+		expectedCauses      []metav1.StatusCause
+	}{
+		{
+			desc: "allowed",
+			name: "goodname",
+			expectedAnnotations: map[string]string{
+				banflunder.DecisionAuditAnnotation: banflunder.DecisionAllowed,
+			},
+		},
+		{
+			desc: "banned by a fischer and a policy",
+			name: "badname",
+			expectedAnnotations: map[string]string{
+				banflunder.DecisionAuditAnnotation: banflunder.DecisionBanned,
+				banflunder.FischerAuditAnnotation:  "ban",
+				banflunder.EntryAuditAnnotation:    "badname",
+			},
+			expectedCauses: []metav1.StatusCause{
+				{Type: banflunder.CauseTypeFischerBan, Message: `Fischer "ban" disallows "badname"`, Field: "metadata.name"},
+				{Type: banflunder.CauseTypeFlunderPolicyBan, Message: `FlunderPolicy "policy" disallows "badname"`, Field: "metadata.name"},
+			},
+		},
+		{
+			desc: "banned by a policy",
+			name: "policyname",
+			expectedAnnotations: map[string]string{
+				banflunder.DecisionAuditAnnotation:      banflunder.DecisionBanned,
+				banflunder.FlunderPolicyAuditAnnotation: "policy",
+				banflunder.EntryAuditAnnotation:         "policyname",
+			},
+			expectedCauses: []metav1.StatusCause{
+				{Type: banflunder.CauseTypeFlunderPolicyBan, Message: `FlunderPolicy "policy" disallows "policyname"`, Field: "metadata.name"},
+			},
+		},
+	}
+
+	cs := fake.NewSimpleClientset(
+		&wardle.Fischer{ObjectMeta: metav1.ObjectMeta{Name: "ban"}, DisallowedFlunders: []string{"badname"}},
+		&wardle.FlunderPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "team"},
+			Spec:       wardle.FlunderPolicySpec{DisallowedFlunders: []string{"badname", "policyname"}},
+		},
+	)
+	informersFactory := informers.NewSharedInformerFactory(cs, 0)
+	target, err := banflunder.New()
+	require.NoError(t, err)
+	wardleinitializer.New(informersFactory, cs).Initialize(target)
+	require.NoError(t, admission.ValidateInitialization(target))
+	stop := make(chan struct{})
+	defer close(stop)
+	informersFactory.Start(stop)
+	informersFactory.WaitForCacheSync(stop)
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			flunder := &wardle.Flunder{ObjectMeta: metav1.ObjectMeta{Name: tc.name, Namespace: "team"}}
+			attrs := &annotatedAttributes{
+				Attributes: admission.NewAttributesRecord(flunder, nil,
+					wardle.SchemeGroupVersion.WithKind("Flunder").GroupKind().WithVersion("version"), "team", tc.name,
+					wardle.Resource("flunders").WithVersion("version"), "",
+					admission.Create, &metav1.CreateOptions{}, false, nil),
+				annotations: map[string]string{},
+			}
+			err := target.Admit(context.TODO(), attrs, nil)
+			assert.Equal(t, tc.expectedAnnotations, attrs.annotations)
+			if len(tc.expectedCauses) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.True(t, apierrors.IsForbidden(err), "expected Forbidden, got %v", err)
+			assert.Equal(t, tc.expectedCauses, err.(apierrors.APIStatus).Status().Details.Causes)
+		})
+	}
+}
+
+func TestBanflunderPoliciesNotServed(t *testing.T) {
+	cs := fake.NewSimpleClientset(
+		&wardle.FlunderPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "team"},
+			Spec:       wardle.FlunderPolicySpec{DisallowedFlunders: []string{"policyname"}},
+		},
+	)
+	informersFactory := informers.NewSharedInformerFactory(cs, 0)
+	target, err := banflunder.New()
+	require.NoError(t, err)
+	wardleinitializer.New(informersFactory, cs).WithServedKinds(func(obj runtime.Object) bool {
+		_, isPolicy := obj.(*wardle.FlunderPolicy)
+		return !isPolicy
+	}).Initialize(target)
+	require.NoError(t, admission.ValidateInitialization(target))
+	stop := make(chan struct{})
+	defer close(stop)
+	informersFactory.Start(stop)
+	synced := informersFactory.WaitForCacheSync(stop)
+	assert.Len(t, synced, 1, "only the fischer informer must be started")
+
+	flunder := &wardle.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "policyname", Namespace: "team"}}
+	attrs := admission.NewAttributesRecord(flunder, nil,
+		wardle.SchemeGroupVersion.WithKind("Flunder").GroupKind().WithVersion("version"), "team", "policyname",
+		wardle.Resource("flunders").WithVersion("version"), "",
+		admission.Create, &metav1.CreateOptions{}, false, nil)
+	assert.NoError(t, target.Admit(context.TODO(), attrs, nil), "policies which are not served must not ban")
+}
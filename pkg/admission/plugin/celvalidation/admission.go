@@ -0,0 +1,281 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package celvalidation implements an admission plugin which validates wardle
+// objects with CEL expressions.
+package celvalidation
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apiserver/pkg/admission"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/environment"
+	"k8s.io/apiserver/pkg/cel/openapi"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/install"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	sampleopenapi "k8s.io/sample-apiserver/pkg/generated/openapi"
+	"sigs.k8s.io/yaml"
+)
+
+// PluginName is the name of the plugin.
+const PluginName = "CELValidation"
+
+const (
+	objectVarName    = "object"
+	oldObjectVarName = "oldObject"
+)
+
+// Configuration is the configuration of the plugin, read from the admission
+// configuration file.
+type Configuration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Rules are the validation rules. An object is admitted if all rules
+	// matching it evaluate to true.
+	Rules []Rule `json:"rules"`
+}
+
+// Rule validates objects of one kind with a CEL expression.
+type Rule struct {
+	// Kind is the wardle kind of the validated objects, Flunder or Fischer.
+	Kind string `json:"kind"`
+	// Version is the version of the wardle API in which the expression sees
+	// the objects. Defaults to v1alpha1.
+	Version string `json:"version,omitempty"`
+	// Operations are the validated operations, CREATE or UPDATE. Defaults to
+	// both.
+	Operations []admission.Operation `json:"operations,omitempty"`
+	// Expression is a CEL expression which must evaluate to true for the
+	// object to be admitted. The object is bound to the variable object and,
+	// on updates, the old object to oldObject. oldObject is null on creates.
+	Expression string `json:"expression"`
+	// Message is returned to the client when the expression evaluates to
+	// false. Defaults to the expression.
+	Message string `json:"message,omitempty"`
+}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
+		configuration, err := loadConfiguration(config)
+		if err != nil {
+			return nil, err
+		}
+		return New(configuration)
+	})
+}
+
+func loadConfiguration(config io.Reader) (*Configuration, error) {
+	configuration := &Configuration{}
+	if config == nil {
+		return configuration, nil
+	}
+	data, err := io.ReadAll(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(data, configuration); err != nil {
+		return nil, fmt.Errorf("failed to decode the %s configuration: %w", PluginName, err)
+	}
+	return configuration, nil
+}
+
+// Validation is a CEL validation admission plugin
+type Validation struct {
+	*admission.Handler
+	rules []*compiledRule
+}
+
+var _ admission.ValidationInterface = &Validation{}
+
+type compiledRule struct {
+	Rule
+	gvk        schema.GroupVersionKind
+	schema     *spec.Schema
+	operations sets.Set[admission.Operation]
+	program    cel.Program
+}
+
+// Validate evaluates the rules matching the kind of the object in-flight and
+// rejects it if any of them evaluates to false.
+func (v *Validation) Validate(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	if len(a.GetSubresource()) != 0 {
+		return nil
+	}
+	for _, rule := range v.rules {
+		if rule.gvk.GroupKind() != a.GetKind().GroupKind() || !rule.operations.Has(a.GetOperation()) {
+			continue
+		}
+		admitted, err := rule.eval(ctx, a, o)
+		if err != nil {
+			return admission.NewForbidden(a, fmt.Errorf("expression '%s' failed: %w", rule.Expression, err))
+		}
+		if !admitted {
+			return admission.NewForbidden(a, fmt.Errorf("%s", rule.message()))
+		}
+	}
+	return nil
+}
+
+func (r *compiledRule) message() string {
+	if len(r.Message) != 0 {
+		return r.Message
+	}
+	return fmt.Sprintf("failed expression: %s", r.Expression)
+}
+
+func (r *compiledRule) eval(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) (bool, error) {
+	object, err := r.toVal(a.GetObject(), o)
+	if err != nil {
+		return false, err
+	}
+	oldObject, err := r.toVal(a.GetOldObject(), o)
+	if err != nil {
+		return false, err
+	}
+	result, _, err := r.program.ContextEval(ctx, map[string]any{
+		objectVarName:    object,
+		oldObjectVarName: oldObject,
+	})
+	if err != nil {
+		return false, err
+	}
+	admitted, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a bool result, got %v", result.Type())
+	}
+	return admitted, nil
+}
+
+// toVal converts obj to the version of the rule and returns it as a CEL value
+// typed by the OpenAPI schema of that version.
+func (r *compiledRule) toVal(obj runtime.Object, o admission.ObjectInterfaces) (ref.Val, error) {
+	if obj == nil {
+		return types.NullValue, nil
+	}
+	versioned, err := o.GetObjectConvertor().ConvertToVersion(obj, r.gvk.GroupVersion())
+	if err != nil {
+		return nil, err
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(versioned)
+	if err != nil {
+		return nil, err
+	}
+	return openapi.UnstructuredToVal(u, r.schema), nil
+}
+
+// New compiles the rules of the configuration against the OpenAPI
+// definitions of the wardle types, and creates a new CEL validation admission
+// plugin.
+func New(configuration *Configuration) (*Validation, error) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	schemaResolver := resolver.NewDefinitionsSchemaResolver(sampleopenapi.GetOpenAPIDefinitions, scheme)
+
+	var rules []*compiledRule
+	var errs []error
+	for i, rule := range configuration.Rules {
+		compiled, err := compile(rule, schemaResolver)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rules[%d]: %w", i, err))
+			continue
+		}
+		rules = append(rules, compiled)
+	}
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("invalid %s configuration: %w", PluginName, utilerrors.NewAggregate(errs))
+	}
+
+	return &Validation{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+		rules:   rules,
+	}, nil
+}
+
+func compile(rule Rule, schemaResolver *resolver.DefinitionsSchemaResolver) (*compiledRule, error) {
+	if len(rule.Version) == 0 {
+		rule.Version = v1alpha1.SchemeGroupVersion.Version
+	}
+	if len(rule.Operations) == 0 {
+		rule.Operations = []admission.Operation{admission.Create, admission.Update}
+	}
+	for _, operation := range rule.Operations {
+		if operation != admission.Create && operation != admission.Update {
+			return nil, fmt.Errorf("unsupported operation %q, must be %s or %s", operation, admission.Create, admission.Update)
+		}
+	}
+	if len(rule.Expression) == 0 {
+		return nil, fmt.Errorf("expression must not be empty")
+	}
+
+	gvk := schema.GroupVersionKind{Group: wardle.GroupName, Version: rule.Version, Kind: rule.Kind}
+	s, err := schemaResolver.ResolveSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	declType := openapi.SchemaDeclType(s, true).MaybeAssignTypeName(fmt.Sprintf("%s.%s.%s", gvk.Group, gvk.Version, gvk.Kind))
+	envSet, err := environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion(), true).Extend(
+		environment.VersionedOptions{
+			IntroducedVersion: version.MajorMinor(1, 0),
+			EnvOptions: []cel.EnvOption{
+				cel.Variable(objectVarName, declType.CelType()),
+				cel.Variable(oldObjectVarName, declType.CelType()),
+			},
+			DeclTypes: []*apiservercel.DeclType{declType},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	env := envSet.NewExpressionsEnv()
+
+	ast, issues := env.Compile(rule.Expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("compilation failed: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("expression must evaluate to bool, not %v", ast.OutputType())
+	}
+	program, err := env.Program(ast, cel.CostLimit(celconfig.PerCallLimit), cel.InterruptCheckFrequency(celconfig.CheckFrequency))
+	if err != nil {
+		return nil, err
+	}
+
+	return &compiledRule{
+		Rule:       rule,
+		gvk:        gvk,
+		schema:     s,
+		operations: sets.New(rule.Operations...),
+		program:    program,
+	}, nil
+}
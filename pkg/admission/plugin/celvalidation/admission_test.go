@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celvalidation_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/celvalidation"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/install"
+)
+
+func objectInterfaces() admission.ObjectInterfaces {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	return admission.NewObjectInterfacesFromScheme(scheme)
+}
+
+func flunderAttributes(flunder, oldFlunder *wardle.Flunder) admission.Attributes {
+	operation := admission.Create
+	var oldObject runtime.Object
+	if oldFlunder != nil {
+		operation = admission.Update
+		oldObject = oldFlunder
+	}
+	return admission.NewAttributesRecord(flunder, oldObject,
+		wardle.Kind("Flunder").WithVersion("version"), flunder.Namespace, flunder.Name,
+		wardle.Resource("flunders").WithVersion("version"), "",
+		operation, nil, false, nil)
+}
+
+func newFlunder(namespace, name, reference string) *wardle.Flunder {
+	flunder := &wardle.Flunder{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	if len(reference) != 0 {
+		flunder.Spec = wardle.FlunderSpec{FlunderReference: reference, ReferenceType: wardle.FlunderReferenceType}
+	}
+	return flunder
+}
+
+func TestValidate(t *testing.T) {
+	rules := []celvalidation.Rule{
+		{
+			Kind:       "Flunder",
+			Expression: "object.metadata.namespace != 'team' || object.metadata.name.startsWith('team-')",
+			Message:    "flunder names in namespace team must start with team-",
+		},
+		{
+			Kind:       "Flunder",
+			Operations: []admission.Operation{admission.Update},
+			Expression: "object.spec.reference == oldObject.spec.reference",
+		},
+		{
+			Kind:       "Fischer",
+			Version:    "v1beta1",
+			Expression: "!('root' in object.disallowedFlunders)",
+		},
+	}
+
+	testCases := []struct {
+		desc        string
+		attributes  admission.Attributes
+		expectError string
+	}{
+		{
+			desc:       "matching name",
+			attributes: flunderAttributes(newFlunder("team", "team-a", ""), nil),
+		},
+		{
+			desc:        "mismatching name",
+			attributes:  flunderAttributes(newFlunder("team", "a", ""), nil),
+			expectError: "flunder names in namespace team must start with team-",
+		},
+		{
+			desc:       "other namespace",
+			attributes: flunderAttributes(newFlunder("other", "a", ""), nil),
+		},
+		{
+			desc:       "unchanged reference",
+			attributes: flunderAttributes(newFlunder("other", "a", "b"), newFlunder("other", "a", "b")),
+		},
+		{
+			desc:        "changed reference",
+			attributes:  flunderAttributes(newFlunder("other", "a", "c"), newFlunder("other", "a", "b")),
+			expectError: "failed expression: object.spec.reference == oldObject.spec.reference",
+		},
+		{
+			desc: "fischer in v1beta1",
+			attributes: admission.NewAttributesRecord(&wardle.Fischer{
+				ObjectMeta:         metav1.ObjectMeta{Name: "fischer"},
+				DisallowedFlunders: []string{"root"},
+			}, nil,
+				wardle.Kind("Fischer").WithVersion("version"), "", "fischer",
+				wardle.Resource("fischers").WithVersion("version"), "",
+				admission.Create, nil, false, nil),
+			expectError: "failed expression: !('root' in object.disallowedFlunders)",
+		},
+		{
+			desc: "status subresource",
+			attributes: admission.NewAttributesRecord(newFlunder("team", "a", ""), newFlunder("team", "a", ""),
+				wardle.Kind("Flunder").WithVersion("version"), "team", "a",
+				wardle.Resource("flunders").WithVersion("version"), "status",
+				admission.Update, nil, false, nil),
+		},
+	}
+
+	plugin, err := celvalidation.New(&celvalidation.Configuration{Rules: rules})
+	require.NoError(t, err)
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := plugin.Validate(context.Background(), tc.attributes, objectInterfaces())
+			if len(tc.expectError) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, apierrors.IsForbidden(err), "expected Forbidden, got %v", err)
+			assert.Contains(t, err.Error(), tc.expectError)
+		})
+	}
+}
+
+func TestNewCompilesRules(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		rule        celvalidation.Rule
+		expectError string
+	}{
+		{
+			desc:        "unknown field",
+			rule:        celvalidation.Rule{Kind: "Flunder", Expression: "object.spec.color == 'red'"},
+			expectError: "undefined field 'color'",
+		},
+		{
+			desc:        "unknown kind",
+			rule:        celvalidation.Rule{Kind: "Flounder", Expression: "true"},
+			expectError: "cannot resolve",
+		},
+		{
+			desc:        "field of another version",
+			rule:        celvalidation.Rule{Kind: "Fischer", Version: "v1alpha1", Expression: "object.spec.reference == ''"},
+			expectError: "undefined field 'spec'",
+		},
+		{
+			desc:        "not a bool",
+			rule:        celvalidation.Rule{Kind: "Flunder", Expression: "object.metadata.name"},
+			expectError: "must evaluate to bool",
+		},
+		{
+			desc:        "unsupported operation",
+			rule:        celvalidation.Rule{Kind: "Flunder", Operations: []admission.Operation{admission.Delete}, Expression: "true"},
+			expectError: `unsupported operation "DELETE"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := celvalidation.New(&celvalidation.Configuration{Rules: []celvalidation.Rule{tc.rule}})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "rules[0]")
+			assert.Contains(t, err.Error(), tc.expectError)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	plugins := admission.NewPlugins()
+	celvalidation.Register(plugins)
+
+	config := `
+rules:
+- kind: Flunder
+  expression: "object.metadata.name != 'forbidden'"
+`
+	plugin, err := plugins.InitPlugin(celvalidation.PluginName, strings.NewReader(config), admission.PluginInitializers{})
+	require.NoError(t, err)
+	validator := plugin.(admission.ValidationInterface)
+	assert.Error(t, validator.Validate(context.Background(), flunderAttributes(newFlunder("", "forbidden", ""), nil), objectInterfaces()))
+	assert.NoError(t, validator.Validate(context.Background(), flunderAttributes(newFlunder("", "allowed", ""), nil), objectInterfaces()))
+
+	_, err = plugins.InitPlugin(celvalidation.PluginName, strings.NewReader("rulez: []"), admission.PluginInitializers{})
+	assert.Error(t, err, "unknown fields must be rejected")
+
+	// without configuration no rules are evaluated
+	plugin, err = plugins.InitPlugin(celvalidation.PluginName, nil, admission.PluginInitializers{})
+	require.NoError(t, err)
+	assert.NoError(t, plugin.(admission.ValidationInterface).Validate(context.Background(), flunderAttributes(newFlunder("", "forbidden", ""), nil), objectInterfaces()))
+}
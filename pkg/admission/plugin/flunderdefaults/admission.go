@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flunderdefaults
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/sample-apiserver/pkg/admission/wardleinitializer"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+	listers "k8s.io/sample-apiserver/pkg/generated/listers/wardle/v1alpha1"
+)
+
+// PluginName is the name of the plugin.
+const PluginName = "FlunderDefaults"
+
+// MutatedByAuditAnnotation is the audit annotation which lists the Fischers
+// whose defaults were applied to a Flunder.
+const MutatedByAuditAnnotation = "flunderdefaults.admission.wardle.example.com/mutated-by"
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
+		return New()
+	})
+}
+
+// FlunderDefaults is a flunder defaults admission plugin
+type FlunderDefaults struct {
+	*admission.Handler
+	lister listers.FischerLister
+}
+
+var _ admission.MutationInterface = &FlunderDefaults{}
+var _ = wardleinitializer.WantsInternalWardleInformerFactory(&FlunderDefaults{})
+
+// Admit applies the FlunderDefaults of all Fischers whose pattern matches the
+// name of the Flunder in-flight. Fischers are applied in the order of their
+// names, and labels, annotations and references which are already set are
+// kept, so that the first Fischer setting a value wins.
+func (d *FlunderDefaults) Admit(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	if a.GetKind().GroupKind() != wardle.Kind("Flunder") || len(a.GetSubresource()) != 0 {
+		return nil
+	}
+	flunder, ok := a.GetObject().(*wardle.Flunder)
+	if !ok {
+		return nil
+	}
+
+	if !d.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	fischers, err := d.lister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	sort.Slice(fischers, func(i, j int) bool { return fischers[i].Name < fischers[j].Name })
+
+	var mutatedBy []string
+	for _, fischer := range fischers {
+		mutated := false
+		for _, defaults := range fischer.FlunderDefaults {
+			if matched, _ := path.Match(defaults.NamePattern, flunder.Name); !matched {
+				continue
+			}
+			if apply(flunder, &defaults) {
+				mutated = true
+			}
+		}
+		if mutated {
+			mutatedBy = append(mutatedBy, fischer.Name)
+		}
+	}
+	if len(mutatedBy) == 0 {
+		return nil
+	}
+	return a.AddAnnotation(MutatedByAuditAnnotation, strings.Join(mutatedBy, ","))
+}
+
+// apply applies defaults to flunder and returns whether flunder was changed.
+func apply(flunder *wardle.Flunder, defaults *v1alpha1.FlunderDefaults) bool {
+	mutated := false
+	for key, value := range defaults.Labels {
+		if _, found := flunder.Labels[key]; found {
+			continue
+		}
+		if flunder.Labels == nil {
+			flunder.Labels = map[string]string{}
+		}
+		flunder.Labels[key] = value
+		mutated = true
+	}
+	for key, value := range defaults.Annotations {
+		if _, found := flunder.Annotations[key]; found {
+			continue
+		}
+		if flunder.Annotations == nil {
+			flunder.Annotations = map[string]string{}
+		}
+		flunder.Annotations[key] = value
+		mutated = true
+	}
+
+	spec := &flunder.Spec
+	if len(defaults.Reference) != 0 && len(spec.FlunderReference) == 0 && len(spec.FischerReference) == 0 && len(spec.ReferenceType) == 0 {
+		switch wardle.ReferenceType(defaults.ReferenceType) {
+		case wardle.FischerReferenceType:
+			spec.ReferenceType = wardle.FischerReferenceType
+			spec.FischerReference = defaults.Reference
+		default:
+			spec.ReferenceType = wardle.FlunderReferenceType
+			spec.FlunderReference = defaults.Reference
+		}
+		mutated = true
+	}
+	return mutated
+}
+
+// SetInternalWardleInformerFactory gets Listers from SharedInformerFactory.
+// The lister knows how to lists Fischers.
+func (d *FlunderDefaults) SetInternalWardleInformerFactory(f informers.SharedInformerFactory) {
+	fischers := f.Wardle().V1alpha1().Fischers()
+	d.lister = fischers.Lister()
+	d.SetReadyFunc(fischers.Informer().HasSynced)
+}
+
+// ValidateInitialization checks whether the plugin was correctly initialized.
+func (d *FlunderDefaults) ValidateInitialization() error {
+	if d.lister == nil {
+		return fmt.Errorf("missing fischer lister")
+	}
+	return nil
+}
+
+// New creates a new flunder defaults admission plugin
+func New() (*FlunderDefaults, error) {
+	return &FlunderDefaults{
+		Handler: admission.NewHandler(admission.Create),
+	}, nil
+}
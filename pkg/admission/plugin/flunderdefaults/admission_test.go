@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flunderdefaults_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/flunderdefaults"
+	"k8s.io/sample-apiserver/pkg/admission/wardleinitializer"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/fake"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+)
+
+// annotatedAttributes records the audit annotations added by the plugin.
+type annotatedAttributes struct {
+	admission.Attributes
+	annotations map[string]string
+}
+
+func (a *annotatedAttributes) AddAnnotation(key, value string) error {
+	a.annotations[key] = value
+	return a.Attributes.AddAnnotation(key, value)
+}
+
+func TestAdmit(t *testing.T) {
+	fischers := []runtime.Object{
+		&v1alpha1.Fischer{
+			ObjectMeta: metav1.ObjectMeta{Name: "b"},
+			FlunderDefaults: []v1alpha1.FlunderDefaults{
+				{
+					NamePattern:   "team-*",
+					Labels:        map[string]string{"team": "b", "tier": "backend"},
+					Reference:     "shared",
+					ReferenceType: v1alpha1.FischerReferenceType,
+				},
+			},
+		},
+		&v1alpha1.Fischer{
+			ObjectMeta: metav1.ObjectMeta{Name: "a"},
+			FlunderDefaults: []v1alpha1.FlunderDefaults{
+				{
+					NamePattern: "team-*",
+					Labels:      map[string]string{"team": "a"},
+					Annotations: map[string]string{"owner": "a"},
+				},
+				{
+					NamePattern: "db-?",
+					Reference:   "db",
+				},
+			},
+		},
+		&v1alpha1.Fischer{ObjectMeta: metav1.ObjectMeta{Name: "empty"}},
+	}
+
+	testCases := []struct {
+		desc              string
+		flunder           *wardle.Flunder
+		expected          *wardle.Flunder
+		expectedMutatedBy string
+	}{
+		{
+			desc:    "defaults of several fischers",
+			flunder: &wardle.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "team-x", Namespace: "default"}},
+			expected: &wardle.Flunder{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "team-x",
+					Namespace:   "default",
+					Labels:      map[string]string{"team": "a", "tier": "backend"},
+					Annotations: map[string]string{"owner": "a"},
+				},
+				Spec: wardle.FlunderSpec{FischerReference: "shared", ReferenceType: wardle.FischerReferenceType},
+			},
+			expectedMutatedBy: "a,b",
+		},
+		{
+			desc: "values of the flunder are kept",
+			flunder: &wardle.Flunder{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-x", Namespace: "default", Labels: map[string]string{"team": "x", "tier": "frontend"}},
+				Spec:       wardle.FlunderSpec{FlunderReference: "other", ReferenceType: wardle.FlunderReferenceType},
+			},
+			expected: &wardle.Flunder{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "team-x",
+					Namespace:   "default",
+					Labels:      map[string]string{"team": "x", "tier": "frontend"},
+					Annotations: map[string]string{"owner": "a"},
+				},
+				Spec: wardle.FlunderSpec{FlunderReference: "other", ReferenceType: wardle.FlunderReferenceType},
+			},
+			expectedMutatedBy: "a",
+		},
+		{
+			desc:    "reference type defaults to Flunder",
+			flunder: &wardle.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "default"}},
+			expected: &wardle.Flunder{
+				ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "default"},
+				Spec:       wardle.FlunderSpec{FlunderReference: "db", ReferenceType: wardle.FlunderReferenceType},
+			},
+			expectedMutatedBy: "a",
+		},
+		{
+			desc:     "no matching pattern",
+			flunder:  &wardle.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "db-10", Namespace: "default"}},
+			expected: &wardle.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "db-10", Namespace: "default"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cs := fake.NewSimpleClientset(fischers...)
+			factory := informers.NewSharedInformerFactory(cs, 0)
+			plugin, err := flunderdefaults.New()
+			require.NoError(t, err)
+			wardleinitializer.New(factory, cs).Initialize(plugin)
+			require.NoError(t, plugin.ValidateInitialization())
+			factory.Start(ctx.Done())
+			factory.WaitForCacheSync(ctx.Done())
+
+			attrs := &annotatedAttributes{
+				Attributes: admission.NewAttributesRecord(tc.flunder, nil,
+					wardle.Kind("Flunder").WithVersion("version"), tc.flunder.Namespace, tc.flunder.Name,
+					wardle.Resource("flunders").WithVersion("version"), "",
+					admission.Create, &metav1.CreateOptions{}, false, nil),
+				annotations: map[string]string{},
+			}
+			require.NoError(t, plugin.Admit(ctx, attrs, nil))
+			assert.Equal(t, tc.expected, tc.flunder)
+			assert.Equal(t, tc.expectedMutatedBy, attrs.annotations[flunderdefaults.MutatedByAuditAnnotation])
+		})
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flunderquota
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	quota "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/sample-apiserver/pkg/admission/wardleinitializer"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	clientset "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+	listers "k8s.io/sample-apiserver/pkg/generated/listers/wardle/v1alpha1"
+	wardlequota "k8s.io/sample-apiserver/pkg/quota"
+)
+
+// PluginName is the name of the plugin.
+const PluginName = "FlunderQuota"
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
+		return New()
+	})
+}
+
+// QuotaAdmission is a flunder quota admission plugin
+type QuotaAdmission struct {
+	*admission.Handler
+	lister   listers.FlunderQuotaLister
+	client   clientset.Interface
+	registry quota.Registry
+	served   func(obj runtime.Object) bool
+}
+
+var _ admission.ValidationInterface = &QuotaAdmission{}
+var _ = wardleinitializer.WantsInternalWardleInformerFactory(&QuotaAdmission{})
+var _ = wardleinitializer.WantsServedKinds(&QuotaAdmission{})
+var _ = wardleinitializer.WantsWardleClientSet(&QuotaAdmission{})
+
+// Validate rejects the creation of an object which would exceed the limits of
+// a FlunderQuota in its namespace. Otherwise it charges the usage of the object
+// to the status of the FlunderQuotas, so that concurrent requests cannot exceed
+// the limits together. The FlunderQuota controller corrects the usage if the
+// object is not created after all.
+//
+// The usage in the status must have been calculated by the controller before
+// objects are admitted.
+func (q *QuotaAdmission) Validate(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	if len(a.GetSubresource()) != 0 || !q.quotasServed() {
+		return nil
+	}
+	evaluator := q.registry.Get(a.GetResource().GroupResource())
+	if evaluator == nil || !evaluator.Handles(a) {
+		return nil
+	}
+
+	if !q.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	quotas, err := q.lister.FlunderQuotas(a.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("failed to list flunder quotas: %w", err))
+	}
+	sort.Slice(quotas, func(i, j int) bool { return quotas[i].Name < quotas[j].Name })
+	usage, err := evaluator.Usage(a.GetObject())
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	// check all quotas before charging any of them
+	var charges []*v1alpha1.FlunderQuota
+	for _, fq := range quotas {
+		charged, err := charge(fq, evaluator, usage)
+		if err != nil {
+			return admission.NewForbidden(a, err)
+		}
+		if charged != nil {
+			charges = append(charges, charged)
+		}
+	}
+	if a.IsDryRun() {
+		return nil
+	}
+
+	for _, charged := range charges {
+		if err := q.update(ctx, charged, evaluator, usage); err != nil {
+			return admission.NewForbidden(a, err)
+		}
+	}
+	return nil
+}
+
+// update writes the charged status, and charges the latest version of the
+// quota again on conflicts.
+func (q *QuotaAdmission) update(ctx context.Context, charged *v1alpha1.FlunderQuota, evaluator quota.Evaluator, usage corev1.ResourceList) error {
+	quotas := q.client.WardleV1alpha1().FlunderQuotas(charged.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		_, updateErr := quotas.UpdateStatus(ctx, charged, metav1.UpdateOptions{})
+		if !apierrors.IsConflict(updateErr) {
+			return updateErr
+		}
+		latest, err := quotas.Get(ctx, charged.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		recharged, err := charge(latest, evaluator, usage)
+		if err != nil {
+			return err
+		}
+		if recharged == nil {
+			// the quota no longer limits the resource
+			return nil
+		}
+		charged = recharged
+		return updateErr
+	})
+}
+
+// charge returns a copy of fq with the usage added to its status, or nil if fq
+// does not limit the resources of the usage. It fails if the usage would exceed
+// the limits of fq.
+func charge(fq *v1alpha1.FlunderQuota, evaluator quota.Evaluator, usage corev1.ResourceList) (*v1alpha1.FlunderQuota, error) {
+	matched := evaluator.MatchingResources(quota.ResourceNames(fq.Spec.Hard))
+	if len(matched) == 0 {
+		return nil, nil
+	}
+	for _, name := range matched {
+		if _, found := fq.Status.Used[name]; !found {
+			return nil, fmt.Errorf("status unknown for quota: %s, resource: %s", fq.Name, name)
+		}
+	}
+
+	used := quota.Mask(fq.Status.Used, matched)
+	requested := quota.Mask(usage, matched)
+	newUsage := quota.Add(used, requested)
+	if allowed, exceeded := quota.LessThanOrEqual(newUsage, quota.Mask(fq.Spec.Hard, matched)); !allowed {
+		return nil, fmt.Errorf("exceeded quota: %s, requested: %s, used: %s, limited: %s",
+			fq.Name,
+			prettyPrint(quota.Mask(requested, exceeded)),
+			prettyPrint(quota.Mask(used, exceeded)),
+			prettyPrint(quota.Mask(fq.Spec.Hard, exceeded)))
+	}
+
+	charged := fq.DeepCopy()
+	for name, quantity := range newUsage {
+		charged.Status.Used[name] = quantity
+	}
+	return charged, nil
+}
+
+// prettyPrint formats a resource list like name=quantity,name=quantity.
+func prettyPrint(resources corev1.ResourceList) string {
+	parts := make([]string, 0, len(resources))
+	for name, quantity := range resources {
+		parts = append(parts, string(name)+"="+quantity.String())
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// SetServedKinds sets whether the FlunderQuotas are served. If they are not,
+// the plugin admits all objects.
+func (q *QuotaAdmission) SetServedKinds(served func(obj runtime.Object) bool) {
+	q.served = served
+}
+
+// quotasServed returns whether the FlunderQuotas are served.
+func (q *QuotaAdmission) quotasServed() bool {
+	return q.served == nil || q.served(&v1alpha1.FlunderQuota{})
+}
+
+// SetInternalWardleInformerFactory gets the FlunderQuota lister and the quota
+// evaluators from SharedInformerFactory.
+func (q *QuotaAdmission) SetInternalWardleInformerFactory(f informers.SharedInformerFactory) {
+	if !q.quotasServed() {
+		return
+	}
+	quotas := f.Wardle().V1alpha1().FlunderQuotas()
+	q.lister = quotas.Lister()
+	q.registry = wardlequota.NewRegistry(f)
+	q.SetReadyFunc(quotas.Informer().HasSynced)
+}
+
+// SetWardleClientSet sets the clientset used to charge the FlunderQuotas.
+func (q *QuotaAdmission) SetWardleClientSet(client clientset.Interface) {
+	q.client = client
+}
+
+// ValidateInitialization checks whether the plugin was correctly initialized.
+func (q *QuotaAdmission) ValidateInitialization() error {
+	if !q.quotasServed() {
+		return nil
+	}
+	if q.lister == nil {
+		return fmt.Errorf("missing flunder quota lister")
+	}
+	if q.client == nil {
+		return fmt.Errorf("missing wardle clientset")
+	}
+	return nil
+}
+
+// New creates a new flunder quota admission plugin
+func New() (*QuotaAdmission, error) {
+	return &QuotaAdmission{
+		Handler: admission.NewHandler(admission.Create),
+	}, nil
+}
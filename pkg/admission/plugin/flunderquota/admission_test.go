@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flunderquota_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/flunderquota"
+	"k8s.io/sample-apiserver/pkg/admission/wardleinitializer"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/fake"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+)
+
+func newQuota(name string, hard, used int64) *v1alpha1.FlunderQuota {
+	fq := &v1alpha1.FlunderQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team"},
+		Spec: v1alpha1.FlunderQuotaSpec{
+			Hard: corev1.ResourceList{v1alpha1.ResourceFlunders: *resource.NewQuantity(hard, resource.DecimalSI)},
+		},
+	}
+	if used >= 0 {
+		fq.Status = v1alpha1.FlunderQuotaStatus{
+			Hard: fq.Spec.Hard,
+			Used: corev1.ResourceList{v1alpha1.ResourceFlunders: *resource.NewQuantity(used, resource.DecimalSI)},
+		}
+	}
+	return fq
+}
+
+func newPlugin(t *testing.T, cs *fake.Clientset) *flunderquota.QuotaAdmission {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	plugin, err := flunderquota.New()
+	require.NoError(t, err)
+	factory := informers.NewSharedInformerFactory(cs, 0)
+	wardleinitializer.New(factory, cs).Initialize(plugin)
+	require.NoError(t, plugin.ValidateInitialization())
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+	return plugin
+}
+
+func flunderAttributes(name string, dryRun bool) admission.Attributes {
+	flunder := &wardle.Flunder{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team"}}
+	return admission.NewAttributesRecord(flunder, nil,
+		wardle.Kind("Flunder").WithVersion("version"), "team", name,
+		wardle.Resource("flunders").WithVersion("version"), "",
+		admission.Create, &metav1.CreateOptions{}, dryRun, nil)
+}
+
+func usedFlunders(t *testing.T, cs *fake.Clientset, name string) int64 {
+	t.Helper()
+	fq, err := cs.WardleV1alpha1().FlunderQuotas("team").Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	used := fq.Status.Used[v1alpha1.ResourceFlunders]
+	return used.Value()
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		quotas      []runtime.Object
+		dryRun      bool
+		expectError string
+		expectUsed  map[string]int64
+	}{
+		{
+			desc: "no quota",
+		},
+		{
+			desc:       "below the limit",
+			quotas:     []runtime.Object{newQuota("a", 2, 1), newQuota("b", 5, 0)},
+			expectUsed: map[string]int64{"a": 2, "b": 1},
+		},
+		{
+			desc:        "at the limit",
+			quotas:      []runtime.Object{newQuota("a", 5, 0), newQuota("b", 1, 1)},
+			expectError: "exceeded quota: b, requested: count/flunders.wardle.example.com=1, used: count/flunders.wardle.example.com=1, limited: count/flunders.wardle.example.com=1",
+			expectUsed:  map[string]int64{"a": 0, "b": 1},
+		},
+		{
+			desc:        "unknown usage",
+			quotas:      []runtime.Object{newQuota("a", 5, -1)},
+			expectError: "status unknown for quota: a",
+		},
+		{
+			desc:       "dry run",
+			quotas:     []runtime.Object{newQuota("a", 2, 1)},
+			dryRun:     true,
+			expectUsed: map[string]int64{"a": 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cs := fake.NewSimpleClientset(tc.quotas...)
+			plugin := newPlugin(t, cs)
+
+			err := plugin.Validate(context.Background(), flunderAttributes("flunder", tc.dryRun), nil)
+			if len(tc.expectError) != 0 {
+				require.Error(t, err)
+				assert.True(t, apierrors.IsForbidden(err), "expected Forbidden, got %v", err)
+				assert.Contains(t, err.Error(), tc.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+			for name, used := range tc.expectUsed {
+				assert.Equal(t, used, usedFlunders(t, cs, name), "usage of quota %s", name)
+			}
+		})
+	}
+}
+
+func TestValidateConflict(t *testing.T) {
+	cs := fake.NewSimpleClientset(newQuota("a", 2, 0))
+	plugin := newPlugin(t, cs)
+
+	// another server charged the quota in the meantime
+	conflicts := 0
+	cs.PrependReactor("update", "flunderquotas", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "status" || conflicts > 0 {
+			return false, nil, nil
+		}
+		conflicts++
+		require.NoError(t, cs.Tracker().Update(v1alpha1.SchemeGroupVersion.WithResource("flunderquotas"), newQuota("a", 2, 1), "team"))
+		return true, nil, apierrors.NewConflict(wardle.Resource("flunderquotas"), "a", nil)
+	})
+
+	require.NoError(t, plugin.Validate(context.Background(), flunderAttributes("first", false), nil))
+	assert.Equal(t, 1, conflicts)
+	assert.Equal(t, int64(2), usedFlunders(t, cs, "a"), "the latest usage must be charged")
+}
+
+func TestValidateIgnoresOtherResources(t *testing.T) {
+	cs := fake.NewSimpleClientset(newQuota("a", 0, 0))
+	plugin := newPlugin(t, cs)
+
+	fischer := &wardle.Fischer{ObjectMeta: metav1.ObjectMeta{Name: "fischer"}}
+	attrs := admission.NewAttributesRecord(fischer, nil,
+		wardle.Kind("Fischer").WithVersion("version"), "", "fischer",
+		wardle.Resource("fischers").WithVersion("version"), "",
+		admission.Create, &metav1.CreateOptions{}, false, nil)
+	assert.NoError(t, plugin.Validate(context.Background(), attrs, nil))
+}
+
+func TestValidateQuotasNotServed(t *testing.T) {
+	cs := fake.NewSimpleClientset(newQuota("a", 0, 0))
+	plugin, err := flunderquota.New()
+	require.NoError(t, err)
+	factory := informers.NewSharedInformerFactory(cs, 0)
+	wardleinitializer.New(factory, cs).WithServedKinds(func(runtime.Object) bool { return false }).Initialize(plugin)
+	require.NoError(t, plugin.ValidateInitialization())
+
+	// no informer is started, which would never sync
+	assert.Empty(t, factory.WaitForCacheSync(nil))
+	assert.NoError(t, plugin.Validate(context.Background(), flunderAttributes("flunder", false), nil))
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package wardleinitializer
 
 import (
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/admission"
+	clientset "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
 	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
 )
 
@@ -26,3 +28,17 @@ type WantsInternalWardleInformerFactory interface {
 	SetInternalWardleInformerFactory(informers.SharedInformerFactory)
 	admission.InitializationValidator
 }
+
+// WantsWardleClientSet defines a function which sets the wardle clientset for admission plugins that need it
+type WantsWardleClientSet interface {
+	SetWardleClientSet(clientset.Interface)
+	admission.InitializationValidator
+}
+
+// WantsServedKinds defines a function which tells admission plugins whether a
+// wardle kind is served at the emulated version, for plugins which depend on
+// kinds that older versions do not serve
+type WantsServedKinds interface {
+	SetServedKinds(served func(obj runtime.Object) bool)
+	admission.InitializationValidator
+}
@@ -17,27 +17,46 @@ limitations under the License.
 package wardleinitializer
 
 import (
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/admission"
+	clientset "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
 	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
 )
 
 type pluginInitializer struct {
 	informers informers.SharedInformerFactory
+	client    clientset.Interface
+	served    func(obj runtime.Object) bool
 }
 
 var _ admission.PluginInitializer = pluginInitializer{}
 
 // New creates an instance of wardle admission plugins initializer.
-func New(informers informers.SharedInformerFactory) pluginInitializer {
+func New(informers informers.SharedInformerFactory, client clientset.Interface) pluginInitializer {
 	return pluginInitializer{
 		informers: informers,
+		client:    client,
 	}
 }
 
+// WithServedKinds returns a copy of the initializer which tells the plugins
+// whether a wardle kind is served. Without it, all kinds are served.
+func (i pluginInitializer) WithServedKinds(served func(obj runtime.Object) bool) pluginInitializer {
+	i.served = served
+	return i
+}
+
 // Initialize checks the initialization interfaces implemented by a plugin
 // and provide the appropriate initialization data
 func (i pluginInitializer) Initialize(plugin admission.Interface) {
+	// the served kinds decide which informers the plugins request
+	if wants, ok := plugin.(WantsServedKinds); ok && i.served != nil {
+		wants.SetServedKinds(i.served)
+	}
 	if wants, ok := plugin.(WantsInternalWardleInformerFactory); ok {
 		wants.SetInternalWardleInformerFactory(i.informers)
 	}
+	if wants, ok := plugin.(WantsWardleClientSet); ok {
+		wants.SetWardleClientSet(i.client)
+	}
 }
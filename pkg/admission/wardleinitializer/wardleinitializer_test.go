@@ -21,8 +21,10 @@ import (
 	"testing"
 	"time"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/sample-apiserver/pkg/admission/wardleinitializer"
+	clientset "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
 	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/fake"
 	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
 )
@@ -32,7 +34,7 @@ import (
 func TestWantsInternalWardleInformerFactory(t *testing.T) {
 	cs := &fake.Clientset{}
 	sf := informers.NewSharedInformerFactory(cs, time.Duration(1)*time.Second)
-	target := wardleinitializer.New(sf)
+	target := wardleinitializer.New(sf, cs)
 
 	wantWardleInformerFactory := &wantInternalWardleInformerFactory{}
 	target.Initialize(wantWardleInformerFactory)
@@ -57,3 +59,70 @@ func (f *wantInternalWardleInformerFactory) ValidateInitialization() error
 
 var _ admission.Interface = &wantInternalWardleInformerFactory{}
 var _ wardleinitializer.WantsInternalWardleInformerFactory = &wantInternalWardleInformerFactory{}
+
+// TestWantsWardleClientSet ensures that the clientset is injected
+// when the WantsWardleClientSet interface is implemented by a plugin.
+func TestWantsWardleClientSet(t *testing.T) {
+	cs := &fake.Clientset{}
+	sf := informers.NewSharedInformerFactory(cs, time.Duration(1)*time.Second)
+	target := wardleinitializer.New(sf, cs)
+
+	wantWardleClientSet := &wantWardleClientSet{}
+	target.Initialize(wantWardleClientSet)
+	if wantWardleClientSet.cs != cs {
+		t.Errorf("expected clientset to be initialized")
+	}
+}
+
+// wantWardleClientSet is a test stub that fulfills the WantsWardleClientSet interface
+type wantWardleClientSet struct {
+	cs clientset.Interface
+}
+
+func (f *wantWardleClientSet) SetWardleClientSet(cs clientset.Interface) {
+	f.cs = cs
+}
+func (f *wantWardleClientSet) Admit(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	return nil
+}
+func (f *wantWardleClientSet) Handles(o admission.Operation) bool { return false }
+func (f *wantWardleClientSet) ValidateInitialization() error      { return nil }
+
+var _ admission.Interface = &wantWardleClientSet{}
+var _ wardleinitializer.WantsWardleClientSet = &wantWardleClientSet{}
+
+// TestWantsServedKinds ensures that the served kinds are injected when the
+// WantsServedKinds interface is implemented by a plugin, and only if they are
+// known.
+func TestWantsServedKinds(t *testing.T) {
+	cs := &fake.Clientset{}
+	sf := informers.NewSharedInformerFactory(cs, time.Duration(1)*time.Second)
+
+	wantServedKinds := &wantServedKinds{}
+	wardleinitializer.New(sf, cs).Initialize(wantServedKinds)
+	if wantServedKinds.served != nil {
+		t.Errorf("expected served kinds not to be initialized")
+	}
+
+	wardleinitializer.New(sf, cs).WithServedKinds(func(runtime.Object) bool { return false }).Initialize(wantServedKinds)
+	if wantServedKinds.served == nil {
+		t.Errorf("expected served kinds to be initialized")
+	}
+}
+
+// wantServedKinds is a test stub that fulfills the WantsServedKinds interface
+type wantServedKinds struct {
+	served func(obj runtime.Object) bool
+}
+
+func (f *wantServedKinds) SetServedKinds(served func(obj runtime.Object) bool) {
+	f.served = served
+}
+func (f *wantServedKinds) Admit(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	return nil
+}
+func (f *wantServedKinds) Handles(o admission.Operation) bool { return false }
+func (f *wantServedKinds) ValidateInitialization() error      { return nil }
+
+var _ admission.Interface = &wantServedKinds{}
+var _ wardleinitializer.WantsServedKinds = &wantServedKinds{}
@@ -19,6 +19,7 @@ package fuzzer
 import (
 	fuzz "github.com/google/gofuzz"
 	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
 
 	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
 )
@@ -29,6 +30,14 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 		func(s *wardle.FlunderSpec, c fuzz.Continue) {
 			c.FuzzNoCustom(s) // fuzz self without calling this function again
 
+			// Leave half of the specs random and thus mostly inconsistent, to test
+			// that they survive the conversion to v1alpha1 which has a single
+			// reference field.
+			if c.RandBool() {
+				s.ReferenceType = randomReferenceType(c)
+				return
+			}
+
 			if len(s.FlunderReference) != 0 && len(s.FischerReference) != 0 {
 				s.FischerReference = ""
 			}
@@ -40,5 +49,56 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 				s.ReferenceType = ""
 			}
 		},
+		func(s *v1alpha1.FlunderSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(s) // fuzz self without calling this function again
+
+			// an empty reference type is equivalent to an unset one
+			s.ReferenceType = nil
+			if t := v1alpha1.ReferenceType(randomReferenceType(c)); len(t) != 0 {
+				s.ReferenceType = &t
+			}
+			// like the defaulting, use Flunder for untyped references
+			if s.ReferenceType == nil && len(s.Reference) != 0 {
+				t := v1alpha1.FlunderReferenceType
+				s.ReferenceType = &t
+			}
+		},
+		func(f *v1alpha1.Flunder, c fuzz.Continue) {
+			c.FuzzNoCustom(f) // fuzz self without calling this function again
+
+			// add the references which do not fit into spec.reference
+			var unused []string
+			switch {
+			case f.Spec.ReferenceType == nil:
+				unused = []string{wardle.FlunderReferenceAnnotation, wardle.FischerReferenceAnnotation}
+			case *f.Spec.ReferenceType == v1alpha1.FischerReferenceType:
+				unused = []string{wardle.FlunderReferenceAnnotation}
+			default:
+				unused = []string{wardle.FischerReferenceAnnotation}
+			}
+			for _, annotation := range unused {
+				if c.RandBool() {
+					continue
+				}
+				if f.Annotations == nil {
+					f.Annotations = map[string]string{}
+				}
+				f.Annotations[annotation] = c.RandString() + "x"
+			}
+		},
+	}
+}
+
+// randomReferenceType returns a known, unknown or empty reference type.
+func randomReferenceType(c fuzz.Continue) wardle.ReferenceType {
+	switch c.Intn(4) {
+	case 0:
+		return wardle.FlunderReferenceType
+	case 1:
+		return wardle.FischerReferenceType
+	case 2:
+		return ""
+	default:
+		return wardle.ReferenceType(c.RandString() + "x")
 	}
 }
@@ -17,14 +17,163 @@ limitations under the License.
 package install
 
 import (
+	"math/rand"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
 	"k8s.io/apimachinery/pkg/api/apitesting/roundtrip"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/cbor"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
 	wardlefuzzer "k8s.io/sample-apiserver/pkg/apis/wardle/fuzzer"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
 )
 
 func TestRoundTripTypes(t *testing.T) {
 	roundtrip.RoundTripTestForAPIGroup(t, Install, wardlefuzzer.Funcs)
-	// TODO: enable protobuf generation for the sample-apiserver
-	// roundtrip.RoundTripProtobufTestForAPIGroup(t, Install, wardlefuzzer.Funcs)
+	roundtrip.RoundTripProtobufTestForAPIGroup(t, Install, wardlefuzzer.Funcs)
+}
+
+// TestRoundTripTypesCBOR checks that fuzzed objects of all external wardle
+// kinds survive encoding to CBOR and back.
+func TestRoundTripTypesCBOR(t *testing.T) {
+	scheme := runtime.NewScheme()
+	Install(scheme)
+	f := fuzzer.FuzzerFor(
+		fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, wardlefuzzer.Funcs),
+		rand.NewSource(rand.Int63()),
+		runtimeserializer.NewCodecFactory(scheme),
+	)
+	s := cbor.NewSerializer(scheme, scheme)
+
+	for gvk := range scheme.AllKnownTypes() {
+		// WatchEvents are decoded by the caller, which knows their kind
+		if gvk.Group != wardle.GroupName || gvk.Version == runtime.APIVersionInternal || gvk.Kind == "WatchEvent" {
+			continue
+		}
+		t.Run(gvk.String(), func(t *testing.T) {
+			for i := 0; i < *roundtrip.FuzzIters; i++ {
+				original, err := scheme.New(gvk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.Fuzz(original)
+				original.GetObjectKind().SetGroupVersionKind(gvk)
+
+				data, err := runtime.Encode(s, original)
+				if err != nil {
+					t.Fatalf("failed to encode %#v: %v", original, err)
+				}
+				obj, err := runtime.Decode(s, data)
+				if err != nil {
+					t.Fatalf("failed to decode %#v: %v", original, err)
+				}
+
+				if !apiequality.Semantic.DeepEqual(original, obj) {
+					t.Fatalf("round trip altered the object, diff: %v", cmp.Diff(original, obj))
+				}
+			}
+		})
+	}
+}
+
+// TestRoundTripExternalFlunders checks that external Flunders survive the
+// conversion to the internal version, and through it to the other external
+// version, without loss of data.
+func TestRoundTripExternalFlunders(t *testing.T) {
+	scheme := runtime.NewScheme()
+	Install(scheme)
+	f := fuzzer.FuzzerFor(
+		fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, wardlefuzzer.Funcs),
+		rand.NewSource(rand.Int63()),
+		runtimeserializer.NewCodecFactory(scheme),
+	)
+
+	testCases := []struct {
+		desc     string
+		versions []schema.GroupVersion
+	}{
+		{
+			desc:     "v1alpha1",
+			versions: []schema.GroupVersion{v1alpha1.SchemeGroupVersion},
+		},
+		{
+			desc:     "v1beta1",
+			versions: []schema.GroupVersion{v1beta1.SchemeGroupVersion},
+		},
+		{
+			desc:     "v1alpha1 through v1beta1",
+			versions: []schema.GroupVersion{v1alpha1.SchemeGroupVersion, v1beta1.SchemeGroupVersion},
+		},
+		{
+			desc:     "v1beta1 through v1alpha1",
+			versions: []schema.GroupVersion{v1beta1.SchemeGroupVersion, v1alpha1.SchemeGroupVersion},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			for i := 0; i < *roundtrip.FuzzIters; i++ {
+				original, err := scheme.New(tc.versions[0].WithKind("Flunder"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.Fuzz(original)
+				original.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+
+				// external -> internal -> ... -> internal -> external
+				obj := original.DeepCopyObject()
+				for _, gv := range append(tc.versions[1:], tc.versions[0]) {
+					internal := &wardle.Flunder{}
+					if err := scheme.Convert(obj, internal, nil); err != nil {
+						t.Fatalf("failed to convert %#v to internal: %v", obj, err)
+					}
+					if obj, err = scheme.New(gv.WithKind("Flunder")); err != nil {
+						t.Fatal(err)
+					}
+					if err := scheme.Convert(internal, obj, nil); err != nil {
+						t.Fatalf("failed to convert %#v to %v: %v", internal, gv, err)
+					}
+				}
+
+				if !apiequality.Semantic.DeepEqual(original, obj) {
+					t.Fatalf("round trip altered the object, diff: %v", cmp.Diff(original, obj))
+				}
+			}
+		})
+	}
+}
+
+func TestConvertReferenceAnnotationConflicts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	Install(scheme)
+
+	flunderType := v1alpha1.FlunderReferenceType
+	external := &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{wardle.FlunderReferenceAnnotation: "other"},
+		},
+		Spec: v1alpha1.FlunderSpec{Reference: "foo", ReferenceType: &flunderType},
+	}
+	if err := scheme.Convert(external, &wardle.Flunder{}, nil); err == nil {
+		t.Errorf("expected an error converting %#v, got none", external)
+	}
+
+	internal := &wardle.Flunder{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{wardle.FischerReferenceAnnotation: "other"},
+		},
+		Spec: wardle.FlunderSpec{FlunderReference: "foo", ReferenceType: wardle.FlunderReferenceType},
+	}
+	if err := scheme.Convert(internal, &v1alpha1.Flunder{}, nil); err == nil {
+		t.Errorf("expected an error converting %#v, got none", internal)
+	}
 }
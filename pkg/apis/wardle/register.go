@@ -51,6 +51,11 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&FlunderList{},
 		&Fischer{},
 		&FischerList{},
+		&FlunderBanReview{},
+		&FlunderPolicy{},
+		&FlunderPolicyList{},
+		&FlunderQuota{},
+		&FlunderQuotaList{},
 	)
 	return nil
 }
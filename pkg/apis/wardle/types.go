@@ -16,7 +16,10 @@ limitations under the License.
 
 package wardle
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -38,6 +41,33 @@ const (
 	FischerReferenceType = ReferenceType("Fischer")
 )
 
+const (
+	// FlunderReferenceAnnotation holds the flunder reference of a v1alpha1
+	// Flunder whose spec.reference is used for another reference type. It is
+	// set and removed by the conversion and must not be set by users.
+	FlunderReferenceAnnotation = "wardle.example.com/flunder-reference"
+	// FischerReferenceAnnotation holds the fischer reference of a v1alpha1
+	// Flunder whose spec.reference is used for another reference type. It is
+	// set and removed by the conversion and must not be set by users.
+	FischerReferenceAnnotation = "wardle.example.com/fischer-reference"
+)
+
+// ReservedAnnotations are the annotations used to preserve the references of a
+// v1alpha1 Flunder which do not fit into spec.reference.
+var ReservedAnnotations = []string{FlunderReferenceAnnotation, FischerReferenceAnnotation}
+
+// OwnershipPolicy defines whether a Flunder is owned by the object it
+// references.
+type OwnershipPolicy string
+
+const (
+	// NoneOwnershipPolicy leaves the owner references of a Flunder alone.
+	NoneOwnershipPolicy = OwnershipPolicy("None")
+	// OwnedByReferenceOwnershipPolicy makes the referenced Flunder the owner of
+	// a new Flunder, so that the garbage collector deletes the Flunder with it.
+	OwnedByReferenceOwnershipPolicy = OwnershipPolicy("OwnedByReference")
+)
+
 // FlunderSpec is the specification of a Flunder.
 type FlunderSpec struct {
 	// A name of another flunder, mutually exclusive to the FischerReference.
@@ -46,6 +76,8 @@ type FlunderSpec struct {
 	FischerReference string
 	// The reference type.
 	ReferenceType ReferenceType
+	// Whether the Flunder is owned by the Flunder it references.
+	OwnershipPolicy OwnershipPolicy
 }
 
 // FlunderStatus is the status of a Flunder.
@@ -75,6 +107,32 @@ type Fischer struct {
 
 	// DisallowedFlunders holds a list of Flunder.Names that are disallowed.
 	DisallowedFlunders []string
+	// FlunderDefaults are applied to new Flunders whose name matches their
+	// pattern.
+	FlunderDefaults []FlunderDefaults
+	// NearMissDistance enables warnings for new Flunders whose name is close to
+	// an entry of DisallowedFlunders without being banned. Names which match
+	// an entry case-insensitively, or are within this edit distance of it
+	// ignoring case, are created with a warning. No warnings are returned if
+	// it is not set. It must be between 0 and 3.
+	NearMissDistance *int32
+}
+
+// FlunderDefaults holds defaults for new Flunders whose name matches a pattern.
+type FlunderDefaults struct {
+	// NamePattern is a shell file name pattern, e.g. "team-*", which is
+	// matched against the names of new Flunders.
+	NamePattern string
+	// Labels are added to matching Flunders which do not have them yet.
+	Labels map[string]string
+	// Annotations are added to matching Flunders which do not have them yet.
+	Annotations map[string]string
+	// Reference is set as the reference of matching Flunders without a
+	// reference.
+	Reference string
+	// ReferenceType is the type of Reference, Flunder or Fischer. Defaults to
+	// Flunder.
+	ReferenceType ReferenceType
 }
 
 // +genclient:nonNamespaced
@@ -88,3 +146,123 @@ type FischerList struct {
 	// Items is a list of Fischers
 	Items []Fischer
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FlunderBanReview checks whether a Flunder with the given attributes would be
+// banned by the Fischers in the cluster or the FlunderPolicies of its
+// namespace.
+type FlunderBanReview struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Spec holds the attributes of the proposed Flunder.
+	Spec FlunderBanReviewSpec
+	// Status is filled in by the server and tells whether the Flunder would be banned.
+	Status FlunderBanReviewStatus
+}
+
+// FlunderBanReviewSpec holds the attributes of a proposed Flunder.
+type FlunderBanReviewSpec struct {
+	// Name is the name of the proposed Flunder.
+	Name string
+	// Namespace is the namespace of the proposed Flunder.
+	Namespace string
+}
+
+// FlunderBanReviewStatus tells whether a Flunder would be banned.
+type FlunderBanReviewStatus struct {
+	// Banned is true if the creation of the Flunder would be refused.
+	Banned bool
+	// Bans lists the Fischer and FlunderPolicy entries that ban the Flunder.
+	Bans []FlunderBan
+}
+
+// FlunderBan is a Fischer or FlunderPolicy entry that bans a Flunder.
+type FlunderBan struct {
+	// Fischer is the name of the Fischer holding the entry.
+	Fischer string
+	// FlunderPolicy is the name of the FlunderPolicy in the namespace of the
+	// Flunder holding the entry.
+	FlunderPolicy string
+	// Entry is the entry of the Fischer that matches the Flunder.
+	Entry string
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FlunderPolicy bans or allows Flunder names in its namespace.
+type FlunderPolicy struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec FlunderPolicySpec
+}
+
+// FlunderPolicySpec holds the Flunder names a FlunderPolicy bans or allows.
+type FlunderPolicySpec struct {
+	// DisallowedFlunders holds the names of Flunders which may not be created
+	// in the namespace, unless a FlunderPolicy of the namespace allows them.
+	DisallowedFlunders []string
+	// AllowedFlunders holds the names of Flunders which may be created in the
+	// namespace even if a FlunderPolicy of the namespace disallows them. Names
+	// disallowed by a Fischer cannot be allowed.
+	AllowedFlunders []string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FlunderPolicyList is a list of FlunderPolicy objects.
+type FlunderPolicyList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	// Items is a list of FlunderPolicies
+	Items []FlunderPolicy
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FlunderQuota limits the number of Flunders in its namespace.
+type FlunderQuota struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec   FlunderQuotaSpec
+	Status FlunderQuotaStatus
+}
+
+// ResourceFlunders is the name of the number of Flunders in a namespace in the
+// limits of a FlunderQuota.
+const ResourceFlunders corev1.ResourceName = "count/flunders.wardle.example.com"
+
+// FlunderQuotaSpec holds the limits of a FlunderQuota.
+type FlunderQuotaSpec struct {
+	// Hard is the maximum usage per resource, e.g.
+	// count/flunders.wardle.example.com.
+	Hard corev1.ResourceList
+}
+
+// FlunderQuotaStatus holds the enforced limits and the observed usage.
+type FlunderQuotaStatus struct {
+	// Hard is the set of limits enforced by the server.
+	Hard corev1.ResourceList
+	// Used is the current usage in the namespace.
+	Used corev1.ResourceList
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FlunderQuotaList is a list of FlunderQuota objects.
+type FlunderQuotaList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	// Items is a list of FlunderQuotas
+	Items []FlunderQuota
+}
@@ -17,22 +17,102 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"maps"
+
 	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/sample-apiserver/pkg/apis/wardle"
 )
 
+// Convert_v1alpha1_Flunder_To_wardle_Flunder is an autogenerated conversion function.
+func Convert_v1alpha1_Flunder_To_wardle_Flunder(in *Flunder, out *wardle.Flunder, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_Flunder_To_wardle_Flunder(in, out, s); err != nil {
+		return err
+	}
+
+	// restore the references which were preserved in annotations
+	out.Annotations = maps.Clone(in.Annotations)
+	references := []struct {
+		annotation string
+		reference  *string
+	}{
+		{wardle.FlunderReferenceAnnotation, &out.Spec.FlunderReference},
+		{wardle.FischerReferenceAnnotation, &out.Spec.FischerReference},
+	}
+	for _, r := range references {
+		value := in.Annotations[r.annotation]
+		if len(value) == 0 {
+			continue
+		}
+		if len(*r.reference) != 0 {
+			return fmt.Errorf("annotation %q conflicts with spec.reference %q", r.annotation, *r.reference)
+		}
+		*r.reference = value
+		delete(out.Annotations, r.annotation)
+		if len(out.Annotations) == 0 {
+			out.Annotations = nil
+		}
+	}
+
+	return nil
+}
+
+// Convert_wardle_Flunder_To_v1alpha1_Flunder is an autogenerated conversion function.
+func Convert_wardle_Flunder_To_v1alpha1_Flunder(in *wardle.Flunder, out *Flunder, s conversion.Scope) error {
+	if err := autoConvert_wardle_Flunder_To_v1alpha1_Flunder(in, out, s); err != nil {
+		return err
+	}
+
+	// preserve the references which do not fit into spec.reference in annotations
+	out.Annotations = maps.Clone(in.Annotations)
+	var flunderReference, fischerReference string
+	switch in.Spec.ReferenceType {
+	case wardle.FischerReferenceType:
+		flunderReference = in.Spec.FlunderReference
+	case "":
+		flunderReference = in.Spec.FlunderReference
+		fischerReference = in.Spec.FischerReference
+	default:
+		fischerReference = in.Spec.FischerReference
+	}
+	references := []struct {
+		annotation string
+		reference  string
+	}{
+		{wardle.FlunderReferenceAnnotation, flunderReference},
+		{wardle.FischerReferenceAnnotation, fischerReference},
+	}
+	for _, r := range references {
+		if value, found := in.Annotations[r.annotation]; found && (len(value) != 0 || len(r.reference) != 0) {
+			return fmt.Errorf("annotation %q is reserved for the conversion of references", r.annotation)
+		}
+		if len(r.reference) == 0 {
+			continue
+		}
+		if out.Annotations == nil {
+			out.Annotations = map[string]string{}
+		}
+		out.Annotations[r.annotation] = r.reference
+	}
+
+	return nil
+}
+
 // Convert_v1alpha1_FlunderSpec_To_wardle_FlunderSpec is an autogenerated conversion function.
 func Convert_v1alpha1_FlunderSpec_To_wardle_FlunderSpec(in *FlunderSpec, out *wardle.FlunderSpec, s conversion.Scope) error {
 	if in.ReferenceType != nil {
 		// assume that ReferenceType is defaulted
-		switch *in.ReferenceType {
-		case FlunderReferenceType:
-			out.ReferenceType = wardle.FlunderReferenceType
-			out.FlunderReference = in.Reference
-		case FischerReferenceType:
-			out.ReferenceType = wardle.FischerReferenceType
-			out.FischerReference = in.Reference
-		}
+		out.ReferenceType = wardle.ReferenceType(*in.ReferenceType)
+	}
+	out.OwnershipPolicy = wardle.OwnershipPolicy(in.OwnershipPolicy)
+
+	// Unknown reference types are kept, so that validation can reject them.
+	// Their reference is stored like an untyped one, which defaults to Flunder.
+	switch out.ReferenceType {
+	case wardle.FischerReferenceType:
+		out.FischerReference = in.Reference
+	default:
+		out.FlunderReference = in.Reference
 	}
 
 	return nil
@@ -40,15 +120,20 @@ func Convert_v1alpha1_FlunderSpec_To_wardle_FlunderSpec(in *FlunderSpec, out *wa
 
 // Convert_wardle_FlunderSpec_To_v1alpha1_FlunderSpec is an autogenerated conversion function.
 func Convert_wardle_FlunderSpec_To_v1alpha1_FlunderSpec(in *wardle.FlunderSpec, out *FlunderSpec, s conversion.Scope) error {
-	switch in.ReferenceType {
-	case wardle.FlunderReferenceType:
-		t := FlunderReferenceType
+	if len(in.ReferenceType) != 0 {
+		t := ReferenceType(in.ReferenceType)
 		out.ReferenceType = &t
-		out.Reference = in.FlunderReference
+	}
+	out.OwnershipPolicy = OwnershipPolicy(in.OwnershipPolicy)
+
+	switch in.ReferenceType {
 	case wardle.FischerReferenceType:
-		t := FischerReferenceType
-		out.ReferenceType = &t
 		out.Reference = in.FischerReference
+	case "":
+		// An untyped reference would be defaulted to Flunder on the way back.
+		// Convert_wardle_Flunder_To_v1alpha1_Flunder keeps it in an annotation.
+	default:
+		out.Reference = in.FlunderReference
 	}
 
 	return nil
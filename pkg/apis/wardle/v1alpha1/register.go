@@ -52,6 +52,11 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&FlunderList{},
 		&Fischer{},
 		&FischerList{},
+		&FlunderBanReview{},
+		&FlunderPolicy{},
+		&FlunderPolicyList{},
+		&FlunderQuota{},
+		&FlunderQuotaList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
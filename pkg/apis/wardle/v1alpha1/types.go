@@ -16,7 +16,10 @@ limitations under the License.
 
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +k8s:prerelease-lifecycle-gen:introduced=1.0
@@ -37,11 +40,29 @@ const (
 	FischerReferenceType = ReferenceType("Fischer")
 )
 
+// OwnershipPolicy defines whether a Flunder is owned by the object it
+// references.
+type OwnershipPolicy string
+
+const (
+	// NoneOwnershipPolicy leaves the owner references of a Flunder alone.
+	NoneOwnershipPolicy = OwnershipPolicy("None")
+	// OwnedByReferenceOwnershipPolicy makes the referenced Flunder the owner of
+	// a new Flunder, so that the garbage collector deletes the Flunder with it.
+	OwnedByReferenceOwnershipPolicy = OwnershipPolicy("OwnedByReference")
+)
+
 type FlunderSpec struct {
 	// A name of another flunder or fischer, depending on the reference type.
 	Reference string `json:"reference,omitempty" protobuf:"bytes,1,opt,name=reference"`
 	// The reference type, defaults to "Flunder" if reference is set.
 	ReferenceType *ReferenceType `json:"referenceType,omitempty" protobuf:"bytes,2,opt,name=referenceType"`
+	// Whether the Flunder is owned by the Flunder it references, None if
+	// empty. OwnedByReference requires the reference type Flunder and sets the
+	// owner references when the Flunder is created, so that deleting the
+	// referenced Flunder deletes this one too. It cannot be changed later.
+	// +optional
+	OwnershipPolicy OwnershipPolicy `json:"ownershipPolicy,omitempty" protobuf:"bytes,3,opt,name=ownershipPolicy"`
 }
 
 type FlunderStatus struct {
@@ -73,6 +94,34 @@ type Fischer struct {
 	// DisallowedFlunders holds a list of Flunder.Names that are disallowed.
 	// +listType=atomic
 	DisallowedFlunders []string `json:"disallowedFlunders,omitempty" protobuf:"bytes,2,rep,name=disallowedFlunders"`
+	// FlunderDefaults are applied to new Flunders whose name matches their
+	// pattern.
+	// +listType=atomic
+	FlunderDefaults []FlunderDefaults `json:"flunderDefaults,omitempty" protobuf:"bytes,3,rep,name=flunderDefaults"`
+	// NearMissDistance enables warnings for new Flunders whose name is close to
+	// an entry of DisallowedFlunders without being banned. Names which match
+	// an entry case-insensitively, or are within this edit distance of it
+	// ignoring case, are created with a warning. No warnings are returned if
+	// it is not set. It must be between 0 and 3.
+	// +optional
+	NearMissDistance *int32 `json:"nearMissDistance,omitempty" protobuf:"varint,4,opt,name=nearMissDistance"`
+}
+
+// FlunderDefaults holds defaults for new Flunders whose name matches a pattern.
+type FlunderDefaults struct {
+	// NamePattern is a shell file name pattern, e.g. "team-*", which is
+	// matched against the names of new Flunders.
+	NamePattern string `json:"namePattern" protobuf:"bytes,1,opt,name=namePattern"`
+	// Labels are added to matching Flunders which do not have them yet.
+	Labels map[string]string `json:"labels,omitempty" protobuf:"bytes,2,rep,name=labels"`
+	// Annotations are added to matching Flunders which do not have them yet.
+	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,3,rep,name=annotations"`
+	// Reference is set as the reference of matching Flunders without a
+	// reference.
+	Reference string `json:"reference,omitempty" protobuf:"bytes,4,opt,name=reference"`
+	// ReferenceType is the type of Reference, Flunder or Fischer. Defaults to
+	// Flunder.
+	ReferenceType ReferenceType `json:"referenceType,omitempty" protobuf:"bytes,5,opt,name=referenceType"`
 }
 
 // +genclient:nonNamespaced
@@ -87,3 +136,155 @@ type FischerList struct {
 
 	Items []Fischer `json:"items" protobuf:"bytes,2,rep,name=items"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:prerelease-lifecycle-gen:introduced=1.2
+// +k8s:prerelease-lifecycle-gen:removed=1.10
+
+// FlunderBanReview checks whether a Flunder with the given attributes would be
+// banned by the Fischers in the cluster or the FlunderPolicies of its
+// namespace.
+type FlunderBanReview struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Spec holds the attributes of the proposed Flunder.
+	Spec FlunderBanReviewSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	// Status is filled in by the server and tells whether the Flunder would be banned.
+	// +optional
+	Status FlunderBanReviewStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// FlunderBanReviewSpec holds the attributes of a proposed Flunder.
+type FlunderBanReviewSpec struct {
+	// Name is the name of the proposed Flunder.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Namespace is the namespace of the proposed Flunder.
+	// +optional
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
+}
+
+// FlunderBanReviewStatus tells whether a Flunder would be banned.
+type FlunderBanReviewStatus struct {
+	// Banned is true if the creation of the Flunder would be refused.
+	Banned bool `json:"banned" protobuf:"varint,1,opt,name=banned"`
+	// Bans lists the Fischer and FlunderPolicy entries that ban the Flunder.
+	// +optional
+	// +listType=atomic
+	Bans []FlunderBan `json:"bans,omitempty" protobuf:"bytes,2,rep,name=bans"`
+}
+
+// FlunderBan is a Fischer or FlunderPolicy entry that bans a Flunder.
+type FlunderBan struct {
+	// Fischer is the name of the Fischer holding the entry. It is empty if the
+	// entry is held by a FlunderPolicy.
+	// +optional
+	Fischer string `json:"fischer,omitempty" protobuf:"bytes,1,opt,name=fischer"`
+	// FlunderPolicy is the name of the FlunderPolicy in the namespace of the
+	// Flunder holding the entry. It is empty if the entry is held by a Fischer.
+	// +optional
+	FlunderPolicy string `json:"flunderPolicy,omitempty" protobuf:"bytes,3,opt,name=flunderPolicy"`
+	// Entry is the entry of the Fischer that matches the Flunder.
+	Entry string `json:"entry" protobuf:"bytes,2,opt,name=entry"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:prerelease-lifecycle-gen:introduced=1.2
+// +k8s:prerelease-lifecycle-gen:removed=1.10
+
+// FlunderPolicy bans or allows Flunder names in its namespace. Namespace owners
+// use it to ban names in addition to the Fischers of the cluster admins, which
+// ban names in all namespaces. Names banned by a Fischer cannot be allowed.
+type FlunderPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Spec holds the names the policy bans or allows.
+	Spec FlunderPolicySpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// FlunderPolicySpec holds the Flunder names a FlunderPolicy bans or allows.
+type FlunderPolicySpec struct {
+	// DisallowedFlunders holds the names of Flunders which may not be created
+	// in the namespace, unless a FlunderPolicy of the namespace allows them.
+	// +optional
+	// +listType=set
+	DisallowedFlunders []string `json:"disallowedFlunders,omitempty" protobuf:"bytes,1,rep,name=disallowedFlunders"`
+	// AllowedFlunders holds the names of Flunders which may be created in the
+	// namespace even if a FlunderPolicy of the namespace disallows them. Names
+	// disallowed by a Fischer cannot be allowed.
+	// +optional
+	// +listType=set
+	AllowedFlunders []string `json:"allowedFlunders,omitempty" protobuf:"bytes,2,rep,name=allowedFlunders"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:prerelease-lifecycle-gen:introduced=1.2
+// +k8s:prerelease-lifecycle-gen:removed=1.10
+
+// FlunderPolicyList is a list of FlunderPolicy objects.
+type FlunderPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Items []FlunderPolicy `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:prerelease-lifecycle-gen:introduced=1.2
+// +k8s:prerelease-lifecycle-gen:removed=1.10
+
+// FlunderQuota limits the number of Flunders in its namespace. Its limits use
+// the object count syntax of ResourceQuotas, e.g.
+// count/flunders.wardle.example.com. The server rejects Flunders which would
+// exceed the limits of any FlunderQuota of their namespace.
+type FlunderQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Spec holds the desired limits.
+	Spec FlunderQuotaSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+	// Status holds the enforced limits and the observed usage. It is
+	// maintained by the server.
+	// +optional
+	Status FlunderQuotaStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// ResourceFlunders is the name of the number of Flunders in a namespace in the
+// limits of a FlunderQuota.
+const ResourceFlunders corev1.ResourceName = "count/flunders.wardle.example.com"
+
+// FlunderQuotaSpec holds the limits of a FlunderQuota.
+type FlunderQuotaSpec struct {
+	// Hard is the maximum usage per resource. The only supported resource is
+	// count/flunders.wardle.example.com.
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty" protobuf:"bytes,1,rep,name=hard,casttype=k8s.io/api/core/v1.ResourceList,castkey=k8s.io/api/core/v1.ResourceName"`
+}
+
+// FlunderQuotaStatus holds the enforced limits and the observed usage.
+type FlunderQuotaStatus struct {
+	// Hard is the set of limits enforced by the server.
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty" protobuf:"bytes,1,rep,name=hard,casttype=k8s.io/api/core/v1.ResourceList,castkey=k8s.io/api/core/v1.ResourceName"`
+	// Used is the current usage in the namespace.
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty" protobuf:"bytes,2,rep,name=used,casttype=k8s.io/api/core/v1.ResourceList,castkey=k8s.io/api/core/v1.ResourceName"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:prerelease-lifecycle-gen:introduced=1.2
+// +k8s:prerelease-lifecycle-gen:removed=1.10
+
+// FlunderQuotaList is a list of FlunderQuota objects.
+type FlunderQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Items []FlunderQuota `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
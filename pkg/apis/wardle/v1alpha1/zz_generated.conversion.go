@@ -24,6 +24,7 @@ package v1alpha1
 import (
 	unsafe "unsafe"
 
+	v1 "k8s.io/api/core/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	wardle "k8s.io/sample-apiserver/pkg/apis/wardle"
@@ -56,13 +57,53 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*Flunder)(nil), (*wardle.Flunder)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_Flunder_To_wardle_Flunder(a.(*Flunder), b.(*wardle.Flunder), scope)
+	if err := s.AddGeneratedConversionFunc((*FlunderBan)(nil), (*wardle.FlunderBan)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderBan_To_wardle_FlunderBan(a.(*FlunderBan), b.(*wardle.FlunderBan), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*wardle.Flunder)(nil), (*Flunder)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_wardle_Flunder_To_v1alpha1_Flunder(a.(*wardle.Flunder), b.(*Flunder), scope)
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderBan)(nil), (*FlunderBan)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderBan_To_v1alpha1_FlunderBan(a.(*wardle.FlunderBan), b.(*FlunderBan), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlunderBanReview)(nil), (*wardle.FlunderBanReview)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderBanReview_To_wardle_FlunderBanReview(a.(*FlunderBanReview), b.(*wardle.FlunderBanReview), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderBanReview)(nil), (*FlunderBanReview)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderBanReview_To_v1alpha1_FlunderBanReview(a.(*wardle.FlunderBanReview), b.(*FlunderBanReview), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlunderBanReviewSpec)(nil), (*wardle.FlunderBanReviewSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderBanReviewSpec_To_wardle_FlunderBanReviewSpec(a.(*FlunderBanReviewSpec), b.(*wardle.FlunderBanReviewSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderBanReviewSpec)(nil), (*FlunderBanReviewSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderBanReviewSpec_To_v1alpha1_FlunderBanReviewSpec(a.(*wardle.FlunderBanReviewSpec), b.(*FlunderBanReviewSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlunderBanReviewStatus)(nil), (*wardle.FlunderBanReviewStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderBanReviewStatus_To_wardle_FlunderBanReviewStatus(a.(*FlunderBanReviewStatus), b.(*wardle.FlunderBanReviewStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderBanReviewStatus)(nil), (*FlunderBanReviewStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderBanReviewStatus_To_v1alpha1_FlunderBanReviewStatus(a.(*wardle.FlunderBanReviewStatus), b.(*FlunderBanReviewStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlunderDefaults)(nil), (*wardle.FlunderDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderDefaults_To_wardle_FlunderDefaults(a.(*FlunderDefaults), b.(*wardle.FlunderDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderDefaults)(nil), (*FlunderDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderDefaults_To_v1alpha1_FlunderDefaults(a.(*wardle.FlunderDefaults), b.(*FlunderDefaults), scope)
 	}); err != nil {
 		return err
 	}
@@ -76,6 +117,76 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FlunderPolicy)(nil), (*wardle.FlunderPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderPolicy_To_wardle_FlunderPolicy(a.(*FlunderPolicy), b.(*wardle.FlunderPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderPolicy)(nil), (*FlunderPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderPolicy_To_v1alpha1_FlunderPolicy(a.(*wardle.FlunderPolicy), b.(*FlunderPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlunderPolicyList)(nil), (*wardle.FlunderPolicyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderPolicyList_To_wardle_FlunderPolicyList(a.(*FlunderPolicyList), b.(*wardle.FlunderPolicyList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderPolicyList)(nil), (*FlunderPolicyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderPolicyList_To_v1alpha1_FlunderPolicyList(a.(*wardle.FlunderPolicyList), b.(*FlunderPolicyList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlunderPolicySpec)(nil), (*wardle.FlunderPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderPolicySpec_To_wardle_FlunderPolicySpec(a.(*FlunderPolicySpec), b.(*wardle.FlunderPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderPolicySpec)(nil), (*FlunderPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderPolicySpec_To_v1alpha1_FlunderPolicySpec(a.(*wardle.FlunderPolicySpec), b.(*FlunderPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlunderQuota)(nil), (*wardle.FlunderQuota)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderQuota_To_wardle_FlunderQuota(a.(*FlunderQuota), b.(*wardle.FlunderQuota), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderQuota)(nil), (*FlunderQuota)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderQuota_To_v1alpha1_FlunderQuota(a.(*wardle.FlunderQuota), b.(*FlunderQuota), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlunderQuotaList)(nil), (*wardle.FlunderQuotaList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderQuotaList_To_wardle_FlunderQuotaList(a.(*FlunderQuotaList), b.(*wardle.FlunderQuotaList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderQuotaList)(nil), (*FlunderQuotaList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderQuotaList_To_v1alpha1_FlunderQuotaList(a.(*wardle.FlunderQuotaList), b.(*FlunderQuotaList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlunderQuotaSpec)(nil), (*wardle.FlunderQuotaSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderQuotaSpec_To_wardle_FlunderQuotaSpec(a.(*FlunderQuotaSpec), b.(*wardle.FlunderQuotaSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderQuotaSpec)(nil), (*FlunderQuotaSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderQuotaSpec_To_v1alpha1_FlunderQuotaSpec(a.(*wardle.FlunderQuotaSpec), b.(*FlunderQuotaSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlunderQuotaStatus)(nil), (*wardle.FlunderQuotaStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlunderQuotaStatus_To_wardle_FlunderQuotaStatus(a.(*FlunderQuotaStatus), b.(*wardle.FlunderQuotaStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderQuotaStatus)(nil), (*FlunderQuotaStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderQuotaStatus_To_v1alpha1_FlunderQuotaStatus(a.(*wardle.FlunderQuotaStatus), b.(*FlunderQuotaStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*FlunderStatus)(nil), (*wardle.FlunderStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_FlunderStatus_To_wardle_FlunderStatus(a.(*FlunderStatus), b.(*wardle.FlunderStatus), scope)
 	}); err != nil {
@@ -91,17 +202,29 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*Flunder)(nil), (*wardle.Flunder)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Flunder_To_wardle_Flunder(a.(*Flunder), b.(*wardle.Flunder), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*wardle.FlunderSpec)(nil), (*FlunderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_wardle_FlunderSpec_To_v1alpha1_FlunderSpec(a.(*wardle.FlunderSpec), b.(*FlunderSpec), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*wardle.Flunder)(nil), (*Flunder)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_Flunder_To_v1alpha1_Flunder(a.(*wardle.Flunder), b.(*Flunder), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
 func autoConvert_v1alpha1_Fischer_To_wardle_Fischer(in *Fischer, out *wardle.Fischer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	out.DisallowedFlunders = *(*[]string)(unsafe.Pointer(&in.DisallowedFlunders))
+	out.FlunderDefaults = *(*[]wardle.FlunderDefaults)(unsafe.Pointer(&in.FlunderDefaults))
+	out.NearMissDistance = (*int32)(unsafe.Pointer(in.NearMissDistance))
 	return nil
 }
 
@@ -113,6 +236,8 @@ func Convert_v1alpha1_Fischer_To_wardle_Fischer(in *Fischer, out *wardle.Fischer
 func autoConvert_wardle_Fischer_To_v1alpha1_Fischer(in *wardle.Fischer, out *Fischer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	out.DisallowedFlunders = *(*[]string)(unsafe.Pointer(&in.DisallowedFlunders))
+	out.FlunderDefaults = *(*[]FlunderDefaults)(unsafe.Pointer(&in.FlunderDefaults))
+	out.NearMissDistance = (*int32)(unsafe.Pointer(in.NearMissDistance))
 	return nil
 }
 
@@ -154,11 +279,6 @@ func autoConvert_v1alpha1_Flunder_To_wardle_Flunder(in *Flunder, out *wardle.Flu
 	return nil
 }
 
-// Convert_v1alpha1_Flunder_To_wardle_Flunder is an autogenerated conversion function.
-func Convert_v1alpha1_Flunder_To_wardle_Flunder(in *Flunder, out *wardle.Flunder, s conversion.Scope) error {
-	return autoConvert_v1alpha1_Flunder_To_wardle_Flunder(in, out, s)
-}
-
 func autoConvert_wardle_Flunder_To_v1alpha1_Flunder(in *wardle.Flunder, out *Flunder, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_wardle_FlunderSpec_To_v1alpha1_FlunderSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -170,9 +290,132 @@ func autoConvert_wardle_Flunder_To_v1alpha1_Flunder(in *wardle.Flunder, out *Flu
 	return nil
 }
 
-// Convert_wardle_Flunder_To_v1alpha1_Flunder is an autogenerated conversion function.
-func Convert_wardle_Flunder_To_v1alpha1_Flunder(in *wardle.Flunder, out *Flunder, s conversion.Scope) error {
-	return autoConvert_wardle_Flunder_To_v1alpha1_Flunder(in, out, s)
+func autoConvert_v1alpha1_FlunderBan_To_wardle_FlunderBan(in *FlunderBan, out *wardle.FlunderBan, s conversion.Scope) error {
+	out.Fischer = in.Fischer
+	out.FlunderPolicy = in.FlunderPolicy
+	out.Entry = in.Entry
+	return nil
+}
+
+// Convert_v1alpha1_FlunderBan_To_wardle_FlunderBan is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderBan_To_wardle_FlunderBan(in *FlunderBan, out *wardle.FlunderBan, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderBan_To_wardle_FlunderBan(in, out, s)
+}
+
+func autoConvert_wardle_FlunderBan_To_v1alpha1_FlunderBan(in *wardle.FlunderBan, out *FlunderBan, s conversion.Scope) error {
+	out.Fischer = in.Fischer
+	out.FlunderPolicy = in.FlunderPolicy
+	out.Entry = in.Entry
+	return nil
+}
+
+// Convert_wardle_FlunderBan_To_v1alpha1_FlunderBan is an autogenerated conversion function.
+func Convert_wardle_FlunderBan_To_v1alpha1_FlunderBan(in *wardle.FlunderBan, out *FlunderBan, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderBan_To_v1alpha1_FlunderBan(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlunderBanReview_To_wardle_FlunderBanReview(in *FlunderBanReview, out *wardle.FlunderBanReview, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_FlunderBanReviewSpec_To_wardle_FlunderBanReviewSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_FlunderBanReviewStatus_To_wardle_FlunderBanReviewStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_FlunderBanReview_To_wardle_FlunderBanReview is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderBanReview_To_wardle_FlunderBanReview(in *FlunderBanReview, out *wardle.FlunderBanReview, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderBanReview_To_wardle_FlunderBanReview(in, out, s)
+}
+
+func autoConvert_wardle_FlunderBanReview_To_v1alpha1_FlunderBanReview(in *wardle.FlunderBanReview, out *FlunderBanReview, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_wardle_FlunderBanReviewSpec_To_v1alpha1_FlunderBanReviewSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_wardle_FlunderBanReviewStatus_To_v1alpha1_FlunderBanReviewStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_wardle_FlunderBanReview_To_v1alpha1_FlunderBanReview is an autogenerated conversion function.
+func Convert_wardle_FlunderBanReview_To_v1alpha1_FlunderBanReview(in *wardle.FlunderBanReview, out *FlunderBanReview, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderBanReview_To_v1alpha1_FlunderBanReview(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlunderBanReviewSpec_To_wardle_FlunderBanReviewSpec(in *FlunderBanReviewSpec, out *wardle.FlunderBanReviewSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1alpha1_FlunderBanReviewSpec_To_wardle_FlunderBanReviewSpec is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderBanReviewSpec_To_wardle_FlunderBanReviewSpec(in *FlunderBanReviewSpec, out *wardle.FlunderBanReviewSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderBanReviewSpec_To_wardle_FlunderBanReviewSpec(in, out, s)
+}
+
+func autoConvert_wardle_FlunderBanReviewSpec_To_v1alpha1_FlunderBanReviewSpec(in *wardle.FlunderBanReviewSpec, out *FlunderBanReviewSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_wardle_FlunderBanReviewSpec_To_v1alpha1_FlunderBanReviewSpec is an autogenerated conversion function.
+func Convert_wardle_FlunderBanReviewSpec_To_v1alpha1_FlunderBanReviewSpec(in *wardle.FlunderBanReviewSpec, out *FlunderBanReviewSpec, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderBanReviewSpec_To_v1alpha1_FlunderBanReviewSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlunderBanReviewStatus_To_wardle_FlunderBanReviewStatus(in *FlunderBanReviewStatus, out *wardle.FlunderBanReviewStatus, s conversion.Scope) error {
+	out.Banned = in.Banned
+	out.Bans = *(*[]wardle.FlunderBan)(unsafe.Pointer(&in.Bans))
+	return nil
+}
+
+// Convert_v1alpha1_FlunderBanReviewStatus_To_wardle_FlunderBanReviewStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderBanReviewStatus_To_wardle_FlunderBanReviewStatus(in *FlunderBanReviewStatus, out *wardle.FlunderBanReviewStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderBanReviewStatus_To_wardle_FlunderBanReviewStatus(in, out, s)
+}
+
+func autoConvert_wardle_FlunderBanReviewStatus_To_v1alpha1_FlunderBanReviewStatus(in *wardle.FlunderBanReviewStatus, out *FlunderBanReviewStatus, s conversion.Scope) error {
+	out.Banned = in.Banned
+	out.Bans = *(*[]FlunderBan)(unsafe.Pointer(&in.Bans))
+	return nil
+}
+
+// Convert_wardle_FlunderBanReviewStatus_To_v1alpha1_FlunderBanReviewStatus is an autogenerated conversion function.
+func Convert_wardle_FlunderBanReviewStatus_To_v1alpha1_FlunderBanReviewStatus(in *wardle.FlunderBanReviewStatus, out *FlunderBanReviewStatus, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderBanReviewStatus_To_v1alpha1_FlunderBanReviewStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlunderDefaults_To_wardle_FlunderDefaults(in *FlunderDefaults, out *wardle.FlunderDefaults, s conversion.Scope) error {
+	out.NamePattern = in.NamePattern
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Reference = in.Reference
+	out.ReferenceType = wardle.ReferenceType(in.ReferenceType)
+	return nil
+}
+
+// Convert_v1alpha1_FlunderDefaults_To_wardle_FlunderDefaults is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderDefaults_To_wardle_FlunderDefaults(in *FlunderDefaults, out *wardle.FlunderDefaults, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderDefaults_To_wardle_FlunderDefaults(in, out, s)
+}
+
+func autoConvert_wardle_FlunderDefaults_To_v1alpha1_FlunderDefaults(in *wardle.FlunderDefaults, out *FlunderDefaults, s conversion.Scope) error {
+	out.NamePattern = in.NamePattern
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Reference = in.Reference
+	out.ReferenceType = ReferenceType(in.ReferenceType)
+	return nil
+}
+
+// Convert_wardle_FlunderDefaults_To_v1alpha1_FlunderDefaults is an autogenerated conversion function.
+func Convert_wardle_FlunderDefaults_To_v1alpha1_FlunderDefaults(in *wardle.FlunderDefaults, out *FlunderDefaults, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderDefaults_To_v1alpha1_FlunderDefaults(in, out, s)
 }
 
 func autoConvert_v1alpha1_FlunderList_To_wardle_FlunderList(in *FlunderList, out *wardle.FlunderList, s conversion.Scope) error {
@@ -217,9 +460,176 @@ func Convert_wardle_FlunderList_To_v1alpha1_FlunderList(in *wardle.FlunderList,
 	return autoConvert_wardle_FlunderList_To_v1alpha1_FlunderList(in, out, s)
 }
 
+func autoConvert_v1alpha1_FlunderPolicy_To_wardle_FlunderPolicy(in *FlunderPolicy, out *wardle.FlunderPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_FlunderPolicySpec_To_wardle_FlunderPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_FlunderPolicy_To_wardle_FlunderPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderPolicy_To_wardle_FlunderPolicy(in *FlunderPolicy, out *wardle.FlunderPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderPolicy_To_wardle_FlunderPolicy(in, out, s)
+}
+
+func autoConvert_wardle_FlunderPolicy_To_v1alpha1_FlunderPolicy(in *wardle.FlunderPolicy, out *FlunderPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_wardle_FlunderPolicySpec_To_v1alpha1_FlunderPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_wardle_FlunderPolicy_To_v1alpha1_FlunderPolicy is an autogenerated conversion function.
+func Convert_wardle_FlunderPolicy_To_v1alpha1_FlunderPolicy(in *wardle.FlunderPolicy, out *FlunderPolicy, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderPolicy_To_v1alpha1_FlunderPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlunderPolicyList_To_wardle_FlunderPolicyList(in *FlunderPolicyList, out *wardle.FlunderPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]wardle.FlunderPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_FlunderPolicyList_To_wardle_FlunderPolicyList is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderPolicyList_To_wardle_FlunderPolicyList(in *FlunderPolicyList, out *wardle.FlunderPolicyList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderPolicyList_To_wardle_FlunderPolicyList(in, out, s)
+}
+
+func autoConvert_wardle_FlunderPolicyList_To_v1alpha1_FlunderPolicyList(in *wardle.FlunderPolicyList, out *FlunderPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]FlunderPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_wardle_FlunderPolicyList_To_v1alpha1_FlunderPolicyList is an autogenerated conversion function.
+func Convert_wardle_FlunderPolicyList_To_v1alpha1_FlunderPolicyList(in *wardle.FlunderPolicyList, out *FlunderPolicyList, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderPolicyList_To_v1alpha1_FlunderPolicyList(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlunderPolicySpec_To_wardle_FlunderPolicySpec(in *FlunderPolicySpec, out *wardle.FlunderPolicySpec, s conversion.Scope) error {
+	out.DisallowedFlunders = *(*[]string)(unsafe.Pointer(&in.DisallowedFlunders))
+	out.AllowedFlunders = *(*[]string)(unsafe.Pointer(&in.AllowedFlunders))
+	return nil
+}
+
+// Convert_v1alpha1_FlunderPolicySpec_To_wardle_FlunderPolicySpec is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderPolicySpec_To_wardle_FlunderPolicySpec(in *FlunderPolicySpec, out *wardle.FlunderPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderPolicySpec_To_wardle_FlunderPolicySpec(in, out, s)
+}
+
+func autoConvert_wardle_FlunderPolicySpec_To_v1alpha1_FlunderPolicySpec(in *wardle.FlunderPolicySpec, out *FlunderPolicySpec, s conversion.Scope) error {
+	out.DisallowedFlunders = *(*[]string)(unsafe.Pointer(&in.DisallowedFlunders))
+	out.AllowedFlunders = *(*[]string)(unsafe.Pointer(&in.AllowedFlunders))
+	return nil
+}
+
+// Convert_wardle_FlunderPolicySpec_To_v1alpha1_FlunderPolicySpec is an autogenerated conversion function.
+func Convert_wardle_FlunderPolicySpec_To_v1alpha1_FlunderPolicySpec(in *wardle.FlunderPolicySpec, out *FlunderPolicySpec, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderPolicySpec_To_v1alpha1_FlunderPolicySpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlunderQuota_To_wardle_FlunderQuota(in *FlunderQuota, out *wardle.FlunderQuota, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_FlunderQuotaSpec_To_wardle_FlunderQuotaSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_FlunderQuotaStatus_To_wardle_FlunderQuotaStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_FlunderQuota_To_wardle_FlunderQuota is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderQuota_To_wardle_FlunderQuota(in *FlunderQuota, out *wardle.FlunderQuota, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderQuota_To_wardle_FlunderQuota(in, out, s)
+}
+
+func autoConvert_wardle_FlunderQuota_To_v1alpha1_FlunderQuota(in *wardle.FlunderQuota, out *FlunderQuota, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_wardle_FlunderQuotaSpec_To_v1alpha1_FlunderQuotaSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_wardle_FlunderQuotaStatus_To_v1alpha1_FlunderQuotaStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_wardle_FlunderQuota_To_v1alpha1_FlunderQuota is an autogenerated conversion function.
+func Convert_wardle_FlunderQuota_To_v1alpha1_FlunderQuota(in *wardle.FlunderQuota, out *FlunderQuota, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderQuota_To_v1alpha1_FlunderQuota(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlunderQuotaList_To_wardle_FlunderQuotaList(in *FlunderQuotaList, out *wardle.FlunderQuotaList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]wardle.FlunderQuota)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_FlunderQuotaList_To_wardle_FlunderQuotaList is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderQuotaList_To_wardle_FlunderQuotaList(in *FlunderQuotaList, out *wardle.FlunderQuotaList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderQuotaList_To_wardle_FlunderQuotaList(in, out, s)
+}
+
+func autoConvert_wardle_FlunderQuotaList_To_v1alpha1_FlunderQuotaList(in *wardle.FlunderQuotaList, out *FlunderQuotaList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]FlunderQuota)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_wardle_FlunderQuotaList_To_v1alpha1_FlunderQuotaList is an autogenerated conversion function.
+func Convert_wardle_FlunderQuotaList_To_v1alpha1_FlunderQuotaList(in *wardle.FlunderQuotaList, out *FlunderQuotaList, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderQuotaList_To_v1alpha1_FlunderQuotaList(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlunderQuotaSpec_To_wardle_FlunderQuotaSpec(in *FlunderQuotaSpec, out *wardle.FlunderQuotaSpec, s conversion.Scope) error {
+	out.Hard = *(*v1.ResourceList)(unsafe.Pointer(&in.Hard))
+	return nil
+}
+
+// Convert_v1alpha1_FlunderQuotaSpec_To_wardle_FlunderQuotaSpec is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderQuotaSpec_To_wardle_FlunderQuotaSpec(in *FlunderQuotaSpec, out *wardle.FlunderQuotaSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderQuotaSpec_To_wardle_FlunderQuotaSpec(in, out, s)
+}
+
+func autoConvert_wardle_FlunderQuotaSpec_To_v1alpha1_FlunderQuotaSpec(in *wardle.FlunderQuotaSpec, out *FlunderQuotaSpec, s conversion.Scope) error {
+	out.Hard = *(*v1.ResourceList)(unsafe.Pointer(&in.Hard))
+	return nil
+}
+
+// Convert_wardle_FlunderQuotaSpec_To_v1alpha1_FlunderQuotaSpec is an autogenerated conversion function.
+func Convert_wardle_FlunderQuotaSpec_To_v1alpha1_FlunderQuotaSpec(in *wardle.FlunderQuotaSpec, out *FlunderQuotaSpec, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderQuotaSpec_To_v1alpha1_FlunderQuotaSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlunderQuotaStatus_To_wardle_FlunderQuotaStatus(in *FlunderQuotaStatus, out *wardle.FlunderQuotaStatus, s conversion.Scope) error {
+	out.Hard = *(*v1.ResourceList)(unsafe.Pointer(&in.Hard))
+	out.Used = *(*v1.ResourceList)(unsafe.Pointer(&in.Used))
+	return nil
+}
+
+// Convert_v1alpha1_FlunderQuotaStatus_To_wardle_FlunderQuotaStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FlunderQuotaStatus_To_wardle_FlunderQuotaStatus(in *FlunderQuotaStatus, out *wardle.FlunderQuotaStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlunderQuotaStatus_To_wardle_FlunderQuotaStatus(in, out, s)
+}
+
+func autoConvert_wardle_FlunderQuotaStatus_To_v1alpha1_FlunderQuotaStatus(in *wardle.FlunderQuotaStatus, out *FlunderQuotaStatus, s conversion.Scope) error {
+	out.Hard = *(*v1.ResourceList)(unsafe.Pointer(&in.Hard))
+	out.Used = *(*v1.ResourceList)(unsafe.Pointer(&in.Used))
+	return nil
+}
+
+// Convert_wardle_FlunderQuotaStatus_To_v1alpha1_FlunderQuotaStatus is an autogenerated conversion function.
+func Convert_wardle_FlunderQuotaStatus_To_v1alpha1_FlunderQuotaStatus(in *wardle.FlunderQuotaStatus, out *FlunderQuotaStatus, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderQuotaStatus_To_v1alpha1_FlunderQuotaStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_FlunderSpec_To_wardle_FlunderSpec(in *FlunderSpec, out *wardle.FlunderSpec, s conversion.Scope) error {
 	// WARNING: in.Reference requires manual conversion: does not exist in peer-type
 	// WARNING: in.ReferenceType requires manual conversion: inconvertible types (*k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1.ReferenceType vs k8s.io/sample-apiserver/pkg/apis/wardle.ReferenceType)
+	out.OwnershipPolicy = wardle.OwnershipPolicy(in.OwnershipPolicy)
 	return nil
 }
 
@@ -227,6 +637,7 @@ func autoConvert_wardle_FlunderSpec_To_v1alpha1_FlunderSpec(in *wardle.FlunderSp
 	// WARNING: in.FlunderReference requires manual conversion: does not exist in peer-type
 	// WARNING: in.FischerReference requires manual conversion: does not exist in peer-type
 	// WARNING: in.ReferenceType requires manual conversion: inconvertible types (k8s.io/sample-apiserver/pkg/apis/wardle.ReferenceType vs *k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1.ReferenceType)
+	out.OwnershipPolicy = OwnershipPolicy(in.OwnershipPolicy)
 	return nil
 }
 
@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -35,6 +36,18 @@ func (in *Fischer) DeepCopyInto(out *Fischer) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FlunderDefaults != nil {
+		in, out := &in.FlunderDefaults, &out.FlunderDefaults
+		*out = make([]FlunderDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NearMissDistance != nil {
+		in, out := &in.NearMissDistance, &out.NearMissDistance
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -117,6 +130,117 @@ func (in *Flunder) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderBan) DeepCopyInto(out *FlunderBan) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderBan.
+func (in *FlunderBan) DeepCopy() *FlunderBan {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderBan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderBanReview) DeepCopyInto(out *FlunderBanReview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderBanReview.
+func (in *FlunderBanReview) DeepCopy() *FlunderBanReview {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderBanReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlunderBanReview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderBanReviewSpec) DeepCopyInto(out *FlunderBanReviewSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderBanReviewSpec.
+func (in *FlunderBanReviewSpec) DeepCopy() *FlunderBanReviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderBanReviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderBanReviewStatus) DeepCopyInto(out *FlunderBanReviewStatus) {
+	*out = *in
+	if in.Bans != nil {
+		in, out := &in.Bans, &out.Bans
+		*out = make([]FlunderBan, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderBanReviewStatus.
+func (in *FlunderBanReviewStatus) DeepCopy() *FlunderBanReviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderBanReviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderDefaults) DeepCopyInto(out *FlunderDefaults) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderDefaults.
+func (in *FlunderDefaults) DeepCopy() *FlunderDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlunderList) DeepCopyInto(out *FlunderList) {
 	*out = *in
@@ -150,6 +274,206 @@ func (in *FlunderList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderPolicy) DeepCopyInto(out *FlunderPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderPolicy.
+func (in *FlunderPolicy) DeepCopy() *FlunderPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlunderPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderPolicyList) DeepCopyInto(out *FlunderPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FlunderPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderPolicyList.
+func (in *FlunderPolicyList) DeepCopy() *FlunderPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlunderPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderPolicySpec) DeepCopyInto(out *FlunderPolicySpec) {
+	*out = *in
+	if in.DisallowedFlunders != nil {
+		in, out := &in.DisallowedFlunders, &out.DisallowedFlunders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedFlunders != nil {
+		in, out := &in.AllowedFlunders, &out.AllowedFlunders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderPolicySpec.
+func (in *FlunderPolicySpec) DeepCopy() *FlunderPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderQuota) DeepCopyInto(out *FlunderQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderQuota.
+func (in *FlunderQuota) DeepCopy() *FlunderQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlunderQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderQuotaList) DeepCopyInto(out *FlunderQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FlunderQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderQuotaList.
+func (in *FlunderQuotaList) DeepCopy() *FlunderQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlunderQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderQuotaSpec) DeepCopyInto(out *FlunderQuotaSpec) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderQuotaSpec.
+func (in *FlunderQuotaSpec) DeepCopy() *FlunderQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlunderQuotaStatus) DeepCopyInto(out *FlunderQuotaStatus) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlunderQuotaStatus.
+func (in *FlunderQuotaStatus) DeepCopy() *FlunderQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlunderQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlunderSpec) DeepCopyInto(out *FlunderSpec) {
 	*out = *in
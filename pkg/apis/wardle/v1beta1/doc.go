@@ -18,6 +18,7 @@ limitations under the License.
 // +k8s:deepcopy-gen=package
 // +k8s:conversion-gen=k8s.io/sample-apiserver/pkg/apis/wardle
 // +k8s:defaulter-gen=TypeMeta
+// +k8s:prerelease-lifecycle-gen=true
 // +groupName=wardle.example.com
 
 // Package v1beta1 is the v1beta1 version of the API.
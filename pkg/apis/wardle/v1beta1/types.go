@@ -19,6 +19,8 @@ package v1beta1
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:prerelease-lifecycle-gen:introduced=1.1
+// +k8s:prerelease-lifecycle-gen:removed=1.10
 
 // FlunderList is a list of Flunder objects.
 type FlunderList struct {
@@ -38,6 +40,18 @@ const (
 	FischerReferenceType = ReferenceType("Fischer")
 )
 
+// OwnershipPolicy defines whether a Flunder is owned by the object it
+// references.
+type OwnershipPolicy string
+
+const (
+	// NoneOwnershipPolicy leaves the owner references of a Flunder alone.
+	NoneOwnershipPolicy = OwnershipPolicy("None")
+	// OwnedByReferenceOwnershipPolicy makes the referenced Flunder the owner of
+	// a new Flunder, so that the garbage collector deletes the Flunder with it.
+	OwnedByReferenceOwnershipPolicy = OwnershipPolicy("OwnedByReference")
+)
+
 // FlunderSpec is the specification of a Flunder.
 type FlunderSpec struct {
 	// A name of another flunder, mutually exclusive to the FischerReference.
@@ -46,6 +60,12 @@ type FlunderSpec struct {
 	FischerReference string `json:"fischerReference,omitempty" protobuf:"bytes,2,opt,name=fischerReference"`
 	// The reference type.
 	ReferenceType ReferenceType `json:"referenceType,omitempty" protobuf:"bytes,3,opt,name=referenceType"`
+	// Whether the Flunder is owned by the Flunder it references, None if
+	// empty. OwnedByReference requires the reference type Flunder and sets the
+	// owner references when the Flunder is created, so that deleting the
+	// referenced Flunder deletes this one too. It cannot be changed later.
+	// +optional
+	OwnershipPolicy OwnershipPolicy `json:"ownershipPolicy,omitempty" protobuf:"bytes,4,opt,name=ownershipPolicy"`
 }
 
 // FlunderStatus is the status of a Flunder.
@@ -54,6 +74,8 @@ type FlunderStatus struct {
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:prerelease-lifecycle-gen:introduced=1.1
+// +k8s:prerelease-lifecycle-gen:removed=1.10
 
 // Flunder is an example type with a spec and a status.
 type Flunder struct {
@@ -63,3 +85,62 @@ type Flunder struct {
 	Spec   FlunderSpec   `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
 	Status FlunderStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:prerelease-lifecycle-gen:introduced=1.2
+// +k8s:prerelease-lifecycle-gen:removed=1.10
+
+// Fischer is an example type with a list of disallowed Flunder.Names
+type Fischer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// DisallowedFlunders holds a list of Flunder.Names that are disallowed.
+	// Unlike in v1alpha1, it is a set, so that several field managers can
+	// apply their own names.
+	// +listType=set
+	DisallowedFlunders []string `json:"disallowedFlunders,omitempty" protobuf:"bytes,2,rep,name=disallowedFlunders"`
+	// FlunderDefaults are applied to new Flunders whose name matches their
+	// pattern.
+	// +listType=atomic
+	FlunderDefaults []FlunderDefaults `json:"flunderDefaults,omitempty" protobuf:"bytes,3,rep,name=flunderDefaults"`
+	// NearMissDistance enables warnings for new Flunders whose name is close to
+	// an entry of DisallowedFlunders without being banned. Names which match
+	// an entry case-insensitively, or are within this edit distance of it
+	// ignoring case, are created with a warning. No warnings are returned if
+	// it is not set. It must be between 0 and 3.
+	// +optional
+	NearMissDistance *int32 `json:"nearMissDistance,omitempty" protobuf:"varint,4,opt,name=nearMissDistance"`
+}
+
+// FlunderDefaults holds defaults for new Flunders whose name matches a pattern.
+type FlunderDefaults struct {
+	// NamePattern is a shell file name pattern, e.g. "team-*", which is
+	// matched against the names of new Flunders.
+	NamePattern string `json:"namePattern" protobuf:"bytes,1,opt,name=namePattern"`
+	// Labels are added to matching Flunders which do not have them yet.
+	Labels map[string]string `json:"labels,omitempty" protobuf:"bytes,2,rep,name=labels"`
+	// Annotations are added to matching Flunders which do not have them yet.
+	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,3,rep,name=annotations"`
+	// Reference is set as the reference of matching Flunders without a
+	// reference.
+	Reference string `json:"reference,omitempty" protobuf:"bytes,4,opt,name=reference"`
+	// ReferenceType is the type of Reference, Flunder or Fischer. Defaults to
+	// Flunder.
+	ReferenceType ReferenceType `json:"referenceType,omitempty" protobuf:"bytes,5,opt,name=referenceType"`
+}
+
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:prerelease-lifecycle-gen:introduced=1.2
+// +k8s:prerelease-lifecycle-gen:removed=1.10
+
+// FischerList is a list of Fischer objects.
+type FischerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Items []Fischer `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
@@ -36,6 +36,26 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*Fischer)(nil), (*wardle.Fischer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Fischer_To_wardle_Fischer(a.(*Fischer), b.(*wardle.Fischer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.Fischer)(nil), (*Fischer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_Fischer_To_v1beta1_Fischer(a.(*wardle.Fischer), b.(*Fischer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FischerList)(nil), (*wardle.FischerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_FischerList_To_wardle_FischerList(a.(*FischerList), b.(*wardle.FischerList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FischerList)(nil), (*FischerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FischerList_To_v1beta1_FischerList(a.(*wardle.FischerList), b.(*FischerList), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Flunder)(nil), (*wardle.Flunder)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Flunder_To_wardle_Flunder(a.(*Flunder), b.(*wardle.Flunder), scope)
 	}); err != nil {
@@ -46,6 +66,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FlunderDefaults)(nil), (*wardle.FlunderDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_FlunderDefaults_To_wardle_FlunderDefaults(a.(*FlunderDefaults), b.(*wardle.FlunderDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*wardle.FlunderDefaults)(nil), (*FlunderDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_wardle_FlunderDefaults_To_v1beta1_FlunderDefaults(a.(*wardle.FlunderDefaults), b.(*FlunderDefaults), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*FlunderList)(nil), (*wardle.FlunderList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_FlunderList_To_wardle_FlunderList(a.(*FlunderList), b.(*wardle.FlunderList), scope)
 	}); err != nil {
@@ -79,6 +109,54 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1beta1_Fischer_To_wardle_Fischer(in *Fischer, out *wardle.Fischer, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.DisallowedFlunders = *(*[]string)(unsafe.Pointer(&in.DisallowedFlunders))
+	out.FlunderDefaults = *(*[]wardle.FlunderDefaults)(unsafe.Pointer(&in.FlunderDefaults))
+	out.NearMissDistance = (*int32)(unsafe.Pointer(in.NearMissDistance))
+	return nil
+}
+
+// Convert_v1beta1_Fischer_To_wardle_Fischer is an autogenerated conversion function.
+func Convert_v1beta1_Fischer_To_wardle_Fischer(in *Fischer, out *wardle.Fischer, s conversion.Scope) error {
+	return autoConvert_v1beta1_Fischer_To_wardle_Fischer(in, out, s)
+}
+
+func autoConvert_wardle_Fischer_To_v1beta1_Fischer(in *wardle.Fischer, out *Fischer, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.DisallowedFlunders = *(*[]string)(unsafe.Pointer(&in.DisallowedFlunders))
+	out.FlunderDefaults = *(*[]FlunderDefaults)(unsafe.Pointer(&in.FlunderDefaults))
+	out.NearMissDistance = (*int32)(unsafe.Pointer(in.NearMissDistance))
+	return nil
+}
+
+// Convert_wardle_Fischer_To_v1beta1_Fischer is an autogenerated conversion function.
+func Convert_wardle_Fischer_To_v1beta1_Fischer(in *wardle.Fischer, out *Fischer, s conversion.Scope) error {
+	return autoConvert_wardle_Fischer_To_v1beta1_Fischer(in, out, s)
+}
+
+func autoConvert_v1beta1_FischerList_To_wardle_FischerList(in *FischerList, out *wardle.FischerList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]wardle.Fischer)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_FischerList_To_wardle_FischerList is an autogenerated conversion function.
+func Convert_v1beta1_FischerList_To_wardle_FischerList(in *FischerList, out *wardle.FischerList, s conversion.Scope) error {
+	return autoConvert_v1beta1_FischerList_To_wardle_FischerList(in, out, s)
+}
+
+func autoConvert_wardle_FischerList_To_v1beta1_FischerList(in *wardle.FischerList, out *FischerList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]Fischer)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_wardle_FischerList_To_v1beta1_FischerList is an autogenerated conversion function.
+func Convert_wardle_FischerList_To_v1beta1_FischerList(in *wardle.FischerList, out *FischerList, s conversion.Scope) error {
+	return autoConvert_wardle_FischerList_To_v1beta1_FischerList(in, out, s)
+}
+
 func autoConvert_v1beta1_Flunder_To_wardle_Flunder(in *Flunder, out *wardle.Flunder, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_FlunderSpec_To_wardle_FlunderSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -111,6 +189,34 @@ func Convert_wardle_Flunder_To_v1beta1_Flunder(in *wardle.Flunder, out *Flunder,
 	return autoConvert_wardle_Flunder_To_v1beta1_Flunder(in, out, s)
 }
 
+func autoConvert_v1beta1_FlunderDefaults_To_wardle_FlunderDefaults(in *FlunderDefaults, out *wardle.FlunderDefaults, s conversion.Scope) error {
+	out.NamePattern = in.NamePattern
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Reference = in.Reference
+	out.ReferenceType = wardle.ReferenceType(in.ReferenceType)
+	return nil
+}
+
+// Convert_v1beta1_FlunderDefaults_To_wardle_FlunderDefaults is an autogenerated conversion function.
+func Convert_v1beta1_FlunderDefaults_To_wardle_FlunderDefaults(in *FlunderDefaults, out *wardle.FlunderDefaults, s conversion.Scope) error {
+	return autoConvert_v1beta1_FlunderDefaults_To_wardle_FlunderDefaults(in, out, s)
+}
+
+func autoConvert_wardle_FlunderDefaults_To_v1beta1_FlunderDefaults(in *wardle.FlunderDefaults, out *FlunderDefaults, s conversion.Scope) error {
+	out.NamePattern = in.NamePattern
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Reference = in.Reference
+	out.ReferenceType = ReferenceType(in.ReferenceType)
+	return nil
+}
+
+// Convert_wardle_FlunderDefaults_To_v1beta1_FlunderDefaults is an autogenerated conversion function.
+func Convert_wardle_FlunderDefaults_To_v1beta1_FlunderDefaults(in *wardle.FlunderDefaults, out *FlunderDefaults, s conversion.Scope) error {
+	return autoConvert_wardle_FlunderDefaults_To_v1beta1_FlunderDefaults(in, out, s)
+}
+
 func autoConvert_v1beta1_FlunderList_To_wardle_FlunderList(in *FlunderList, out *wardle.FlunderList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]wardle.Flunder)(unsafe.Pointer(&in.Items))
@@ -137,6 +243,7 @@ func autoConvert_v1beta1_FlunderSpec_To_wardle_FlunderSpec(in *FlunderSpec, out
 	out.FlunderReference = in.FlunderReference
 	out.FischerReference = in.FischerReference
 	out.ReferenceType = wardle.ReferenceType(in.ReferenceType)
+	out.OwnershipPolicy = wardle.OwnershipPolicy(in.OwnershipPolicy)
 	return nil
 }
 
@@ -149,6 +256,7 @@ func autoConvert_wardle_FlunderSpec_To_v1beta1_FlunderSpec(in *wardle.FlunderSpe
 	out.FlunderReference = in.FlunderReference
 	out.FischerReference = in.FischerReference
 	out.ReferenceType = ReferenceType(in.ReferenceType)
+	out.OwnershipPolicy = OwnershipPolicy(in.OwnershipPolicy)
 	return nil
 }
 
@@ -17,6 +17,14 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
+	pathpkg "path"
+
+	corev1 "k8s.io/api/core/v1"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/api/validation/path"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/sample-apiserver/pkg/apis/wardle"
 )
@@ -25,6 +33,11 @@ import (
 func ValidateFlunder(f *wardle.Flunder) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	for _, annotation := range wardle.ReservedAnnotations {
+		if _, found := f.Annotations[annotation]; found {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "annotations").Key(annotation), "is reserved for the conversion of references"))
+		}
+	}
 	allErrs = append(allErrs, ValidateFlunderSpec(&f.Spec, field.NewPath("spec"))...)
 
 	return allErrs
@@ -50,5 +63,228 @@ func ValidateFlunderSpec(s *wardle.FlunderSpec, fldPath *field.Path) field.Error
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("referenceType"), s.ReferenceType, "must be Flunder or Fischer"))
 	}
 
+	switch s.OwnershipPolicy {
+	case "", wardle.NoneOwnershipPolicy:
+	case wardle.OwnedByReferenceOwnershipPolicy:
+		if s.ReferenceType != wardle.FlunderReferenceType {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ownershipPolicy"), s.OwnershipPolicy, "requires referenceType Flunder"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("ownershipPolicy"), s.OwnershipPolicy, []wardle.OwnershipPolicy{wardle.NoneOwnershipPolicy, wardle.OwnedByReferenceOwnershipPolicy}))
+	}
+
+	return allErrs
+}
+
+// ValidateFlunderUpdate validates an update of a Flunder. The ownership policy
+// cannot change, and neither can the reference of a Flunder owned by it,
+// because the owner references are only set on creation.
+func ValidateFlunderUpdate(f, old *wardle.Flunder) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(f.Spec.OwnershipPolicy, old.Spec.OwnershipPolicy, fldPath.Child("ownershipPolicy"))...)
+	if old.Spec.OwnershipPolicy == wardle.OwnedByReferenceOwnershipPolicy {
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(f.Spec.FlunderReference, old.Spec.FlunderReference, fldPath.Child("flunderReference"))...)
+	}
+
+	return allErrs
+}
+
+// maxNearMissDistance is the maximum edit distance of near misses, beyond
+// which most short names would be close to each other.
+const maxNearMissDistance = 3
+
+// ValidateFischer validates a Fischer.
+func ValidateFischer(f *wardle.Fischer) field.ErrorList {
+	return validateFischer(f, sets.New[string]())
+}
+
+// ValidateFischerUpdate validates an update of a Fischer. Names which were
+// already disallowed more than once before remain allowed to be duplicated,
+// like v1alpha1 allowed before disallowedFlunders became a set.
+func ValidateFischerUpdate(f, old *wardle.Fischer) field.ErrorList {
+	return validateFischer(f, duplicates(old.DisallowedFlunders))
+}
+
+func validateFischer(f *wardle.Fischer, allowedDuplicates sets.Set[string]) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	disallowed := sets.New[string]()
+	for i, name := range f.DisallowedFlunders {
+		if disallowed.Has(name) && !allowedDuplicates.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(field.NewPath("disallowedFlunders").Index(i), name))
+		}
+		disallowed.Insert(name)
+	}
+
+	fldPath := field.NewPath("flunderDefaults")
+	for i := range f.FlunderDefaults {
+		allErrs = append(allErrs, ValidateFlunderDefaults(&f.FlunderDefaults[i], fldPath.Index(i))...)
+	}
+	if f.NearMissDistance != nil && (*f.NearMissDistance < 0 || *f.NearMissDistance > maxNearMissDistance) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("nearMissDistance"), *f.NearMissDistance, fmt.Sprintf("must be between 0 and %d", maxNearMissDistance)))
+	}
+
+	return allErrs
+}
+
+// duplicates returns the names which occur more than once.
+func duplicates(names []string) sets.Set[string] {
+	seen, duplicated := sets.New[string](), sets.New[string]()
+	for _, name := range names {
+		if seen.Has(name) {
+			duplicated.Insert(name)
+		}
+		seen.Insert(name)
+	}
+	return duplicated
+}
+
+// ValidateFlunderDefaults validates FlunderDefaults.
+func ValidateFlunderDefaults(d *wardle.FlunderDefaults, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(d.NamePattern) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("namePattern"), "must be a pattern of flunder names"))
+	} else if _, err := pathpkg.Match(d.NamePattern, ""); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("namePattern"), d.NamePattern, err.Error()))
+	}
+	allErrs = append(allErrs, metav1validation.ValidateLabels(d.Labels, fldPath.Child("labels"))...)
+	allErrs = append(allErrs, apimachineryvalidation.ValidateAnnotations(d.Annotations, fldPath.Child("annotations"))...)
+	for _, annotation := range wardle.ReservedAnnotations {
+		if _, found := d.Annotations[annotation]; found {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("annotations").Key(annotation), "is reserved for the conversion of references"))
+		}
+	}
+
+	switch d.ReferenceType {
+	case "", wardle.FlunderReferenceType, wardle.FischerReferenceType:
+		if len(d.ReferenceType) != 0 && len(d.Reference) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("reference"), "must be set if referenceType is set"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("referenceType"), d.ReferenceType, []wardle.ReferenceType{wardle.FlunderReferenceType, wardle.FischerReferenceType}))
+	}
+
+	return allErrs
+}
+
+// ValidateFlunderBanReview validates a FlunderBanReview.
+func ValidateFlunderBanReview(r *wardle.FlunderBanReview) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, ValidateFlunderBanReviewSpec(&r.Spec, field.NewPath("spec"))...)
+
+	return allErrs
+}
+
+// ValidateFlunderBanReviewSpec validates a FlunderBanReviewSpec.
+func ValidateFlunderBanReviewSpec(s *wardle.FlunderBanReviewSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(s.Name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "must be the name of the proposed flunder"))
+	}
+	for _, msg := range path.IsValidPathSegmentName(s.Name) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), s.Name, msg))
+	}
+	if len(s.Namespace) != 0 {
+		for _, msg := range apimachineryvalidation.ValidateNamespaceName(s.Namespace, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), s.Namespace, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateFlunderPolicy validates a FlunderPolicy.
+func ValidateFlunderPolicy(p *wardle.FlunderPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, ValidateFlunderPolicySpec(&p.Spec, field.NewPath("spec"))...)
+
+	return allErrs
+}
+
+// ValidateFlunderPolicySpec validates a FlunderPolicySpec.
+func ValidateFlunderPolicySpec(s *wardle.FlunderPolicySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	disallowed := sets.New[string]()
+	for i, name := range s.DisallowedFlunders {
+		allErrs = append(allErrs, validateFlunderName(name, fldPath.Child("disallowedFlunders").Index(i))...)
+		if disallowed.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("disallowedFlunders").Index(i), name))
+		}
+		disallowed.Insert(name)
+	}
+	allowed := sets.New[string]()
+	for i, name := range s.AllowedFlunders {
+		allErrs = append(allErrs, validateFlunderName(name, fldPath.Child("allowedFlunders").Index(i))...)
+		if allowed.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("allowedFlunders").Index(i), name))
+		}
+		if disallowed.Has(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowedFlunders").Index(i), name, "cannot be disallowed at the same time"))
+		}
+		allowed.Insert(name)
+	}
+
+	return allErrs
+}
+
+// ValidateFlunderQuota validates a FlunderQuota.
+func ValidateFlunderQuota(q *wardle.FlunderQuota) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateQuotaResourceList(q.Spec.Hard, field.NewPath("spec", "hard"))...)
+
+	return allErrs
+}
+
+// ValidateFlunderQuotaStatus validates the status of a FlunderQuota.
+func ValidateFlunderQuotaStatus(q *wardle.FlunderQuota) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateQuotaResourceList(q.Status.Hard, field.NewPath("status", "hard"))...)
+	allErrs = append(allErrs, validateQuotaResourceList(q.Status.Used, field.NewPath("status", "used"))...)
+
+	return allErrs
+}
+
+// supportedQuotaResources are the resources a FlunderQuota can limit.
+var supportedQuotaResources = sets.New(string(wardle.ResourceFlunders))
+
+func validateQuotaResourceList(resources corev1.ResourceList, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for name, quantity := range resources {
+		resPath := fldPath.Key(string(name))
+		if !supportedQuotaResources.Has(string(name)) {
+			allErrs = append(allErrs, field.NotSupported(resPath, name, sets.List(supportedQuotaResources)))
+			continue
+		}
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(resPath, quantity.String(), "must be greater than or equal to 0"))
+		}
+		if quantity.MilliValue()%1000 != 0 {
+			allErrs = append(allErrs, field.Invalid(resPath, quantity.String(), "must be an integer"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateFlunderName(name string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "must be the name of a flunder"))
+	}
+	for _, msg := range path.IsValidPathSegmentName(name) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, msg))
+	}
+
 	return allErrs
 }
@@ -17,29 +17,47 @@ limitations under the License.
 package apiserver
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/apiserver/pkg/registry/rest"
 	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/component-base/featuregate"
 
 	"k8s.io/sample-apiserver/pkg/apis/wardle"
 	"k8s.io/sample-apiserver/pkg/apis/wardle/install"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/controller/flunderquota"
+	"k8s.io/sample-apiserver/pkg/conversionwebhook"
+	clientset "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+	wardleinformers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions/wardle/v1alpha1"
+	listers "k8s.io/sample-apiserver/pkg/generated/listers/wardle/v1alpha1"
 	wardleregistry "k8s.io/sample-apiserver/pkg/registry"
 	fischerstorage "k8s.io/sample-apiserver/pkg/registry/wardle/fischer"
 	flunderstorage "k8s.io/sample-apiserver/pkg/registry/wardle/flunder"
+	flunderbanreviewstorage "k8s.io/sample-apiserver/pkg/registry/wardle/flunderbanreview"
+	flunderpolicystorage "k8s.io/sample-apiserver/pkg/registry/wardle/flunderpolicy"
+	flunderquotastorage "k8s.io/sample-apiserver/pkg/registry/wardle/flunderquota"
 )
 
 var (
 	// Scheme defines methods for serializing and deserializing API objects.
 	Scheme = runtime.NewScheme()
 	// Codecs provides methods for retrieving codecs and serializers for specific
-	// versions and content types.
-	Codecs              = serializer.NewCodecFactory(Scheme)
+	// versions and content types. It serves CBOR if the CBORServing feature is
+	// enabled.
+	Codecs              = NewCodecFactory(Scheme)
 	WardleComponentName = "wardle"
 )
 
+// flunderQuotaResyncPeriod is how often the usage of all FlunderQuotas is
+// recalculated.
+const flunderQuotaResyncPeriod = 5 * time.Minute
+
 func init() {
 	install.Install(Scheme)
 
@@ -61,6 +79,22 @@ func init() {
 // ExtraConfig holds custom apiserver config
 type ExtraConfig struct {
 	// Place you custom config here.
+
+	// SharedInformerFactory provides the wardle informers used by the
+	// admission plugins. FlunderBanReviews are served only if it is set.
+	SharedInformerFactory informers.SharedInformerFactory
+
+	// EnableConversionWebhook serves the conversions between the wardle API
+	// versions as a CustomResourceDefinition conversion webhook.
+	EnableConversionWebhook bool
+
+	// FeatureGate is the wardle feature gate. The registry drops the fields
+	// of disabled features on write. No fields are dropped if it is nil.
+	FeatureGate featuregate.FeatureGate
+
+	// BanFlunderEnabled is whether the BanFlunder admission plugin runs.
+	// FlunderBanReviews report no bans if it does not.
+	BanFlunderEnabled bool
 }
 
 // Config defines the config for the apiserver
@@ -105,20 +139,122 @@ func (c completedConfig) New() (*WardleServer, error) {
 		GenericAPIServer: genericServer,
 	}
 
-	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(wardle.GroupName, Scheme, metav1.ParameterCodec, Codecs)
+	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(wardle.GroupName, Scheme, metav1.ParameterCodec, Codecs.CodecFactory)
+	apiGroupInfo.NegotiatedSerializer = Codecs
+
+	// the flunder registry warns about names close to banned ones, and the
+	// fischer registry about the flunders dry-run changes would ban
+	var fischers listers.FischerLister
+	var flunders wardleinformers.FlunderInformer
+	if c.ExtraConfig.SharedInformerFactory != nil {
+		fischers = c.ExtraConfig.SharedInformerFactory.Wardle().V1alpha1().Fischers().Lister()
+		flunders = c.ExtraConfig.SharedInformerFactory.Wardle().V1alpha1().Flunders()
+	}
 
 	v1alpha1storage := map[string]rest.Storage{}
-	v1alpha1storage["flunders"] = wardleregistry.RESTInPeace(flunderstorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter))
-	v1alpha1storage["fischers"] = wardleregistry.RESTInPeace(fischerstorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter))
+	v1alpha1storage["flunders"] = wardleregistry.RESTInPeace(flunderstorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter, fischers, c.ExtraConfig.FeatureGate))
+	v1alpha1storage["fischers"] = wardleregistry.RESTInPeace(fischerstorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter, flunders, c.ExtraConfig.FeatureGate))
+	v1alpha1storage["flunderpolicies"] = wardleregistry.RESTInPeace(flunderpolicystorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter))
+	flunderQuotaStorage := wardleregistry.RESTInPeace(flunderquotastorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter))
+	v1alpha1storage["flunderquotas"] = flunderQuotaStorage
+	v1alpha1storage["flunderquotas/status"] = flunderquotastorage.NewStatusREST(Scheme, flunderQuotaStorage)
+	servedKinds, err := ServedKinds(c.GenericConfig.EffectiveVersion.EmulationVersion())
+	if err != nil {
+		return nil, err
+	}
+	// the informers of kinds which are not served would never sync
+	if c.ExtraConfig.SharedInformerFactory != nil && servedKinds(&v1alpha1.FlunderBanReview{}) {
+		v1alpha1storage["flunderbanreviews"] = flunderbanreviewstorage.NewREST(
+			c.ExtraConfig.SharedInformerFactory.Wardle().V1alpha1().Fischers(),
+			c.ExtraConfig.SharedInformerFactory.Wardle().V1alpha1().FlunderPolicies(),
+			c.ExtraConfig.BanFlunderEnabled,
+		)
+	}
+	// kinds are hidden when emulating a wardle version older than the one
+	// they were introduced in
+	v1alpha1storage, err = servedStorage(c.GenericConfig.EffectiveVersion.EmulationVersion(), "v1alpha1", v1alpha1storage)
+	if err != nil {
+		return nil, err
+	}
 	apiGroupInfo.VersionedResourcesStorageMap["v1alpha1"] = v1alpha1storage
 
 	v1beta1storage := map[string]rest.Storage{}
-	v1beta1storage["flunders"] = wardleregistry.RESTInPeace(flunderstorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter))
+	v1beta1storage["flunders"] = wardleregistry.RESTInPeace(flunderstorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter, fischers, c.ExtraConfig.FeatureGate))
+	v1beta1storage["fischers"] = wardleregistry.RESTInPeace(fischerstorage.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter, flunders, c.ExtraConfig.FeatureGate))
+
+	// v1beta1 is hidden when emulating a wardle version older than the one it
+	// was introduced in
+	v1beta1storage, err = servedStorage(c.GenericConfig.EffectiveVersion.EmulationVersion(), "v1beta1", v1beta1storage)
+	if err != nil {
+		return nil, err
+	}
 	apiGroupInfo.VersionedResourcesStorageMap["v1beta1"] = v1beta1storage
 
 	if err := s.GenericAPIServer.InstallAPIGroup(&apiGroupInfo); err != nil {
 		return nil, err
 	}
 
+	if c.ExtraConfig.SharedInformerFactory != nil && servedKinds(&v1alpha1.FlunderQuota{}) {
+		client, err := clientset.NewForConfig(c.GenericConfig.LoopbackClientConfig)
+		if err != nil {
+			return nil, err
+		}
+		controller, err := flunderquota.NewController(client, c.ExtraConfig.SharedInformerFactory, flunderQuotaResyncPeriod)
+		if err != nil {
+			return nil, err
+		}
+		s.GenericAPIServer.AddPostStartHookOrDie("start-flunder-quota-controller", func(context genericapiserver.PostStartHookContext) error {
+			go controller.Run(context, 1)
+			return nil
+		})
+	}
+
+	if c.ExtraConfig.EnableConversionWebhook {
+		s.GenericAPIServer.Handler.NonGoRestfulMux.Handle(conversionwebhook.Path, conversionwebhook.NewHandler(Scheme))
+	}
+
 	return s, nil
 }
+
+// servedStorage returns the storage of the resources of the given wardle API
+// version which are served at the emulated version. Resources are served from
+// the version they were introduced in, as recorded by the API lifecycle of
+// their types, so emulating an older version hides them.
+func servedStorage(emulationVersion *version.Version, apiVersion string, storage map[string]rest.Storage) (map[string]rest.Storage, error) {
+	evaluator, err := genericapiserver.NewResourceExpirationEvaluator(emulationVersion)
+	if err != nil {
+		return nil, err
+	}
+	served := map[string]map[string]rest.Storage{apiVersion: storage}
+	evaluator.RemoveDeletedKinds(wardle.GroupName, Scheme, served)
+	return served[apiVersion], nil
+}
+
+// ServedKinds returns a function which tells whether the kind of a versioned
+// wardle object is served at the emulated version, for the admission plugins
+// and controllers which depend on kinds that older versions do not serve.
+func ServedKinds(emulationVersion *version.Version) (func(obj runtime.Object) bool, error) {
+	evaluator, err := genericapiserver.NewResourceExpirationEvaluator(emulationVersion)
+	if err != nil {
+		return nil, err
+	}
+	return func(obj runtime.Object) bool {
+		kinds, _, err := Scheme.ObjectKinds(obj)
+		if err != nil {
+			return false
+		}
+		apiVersion := kinds[0].Version
+		served := map[string]map[string]rest.Storage{apiVersion: {"kind": &kindStorage{obj: obj}}}
+		evaluator.RemoveDeletedKinds(wardle.GroupName, Scheme, served)
+		return len(served[apiVersion]) != 0
+	}, nil
+}
+
+// kindStorage is a storage of objects of one kind, which only tells the kind
+// to the ResourceExpirationEvaluator.
+type kindStorage struct {
+	obj runtime.Object
+}
+
+func (s *kindStorage) New() runtime.Object { return s.obj.DeepCopyObject() }
+func (s *kindStorage) Destroy()            {}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"sync/atomic"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/cbor"
+)
+
+// CBORServingFeature is the wardle feature which makes the server accept and
+// serve application/cbor.
+const CBORServingFeature = "CBORServing"
+
+// CodecFactory is a serializer.CodecFactory which can also accept and serve
+// application/cbor. serializer.NewCodecFactory does not offer CBOR yet, so it
+// is added to the supported media types once SetCBOREnabled(true) is called.
+// Copies of a CodecFactory share that setting.
+type CodecFactory struct {
+	serializer.CodecFactory
+
+	accepts         []runtime.SerializerInfo
+	acceptsWithCBOR []runtime.SerializerInfo
+	cborEnabled     *atomic.Bool
+}
+
+var _ runtime.NegotiatedSerializer = CodecFactory{}
+
+// NewCodecFactory returns a CodecFactory for the given scheme with CBOR
+// disabled.
+func NewCodecFactory(scheme *runtime.Scheme) CodecFactory {
+	codecs := serializer.NewCodecFactory(scheme)
+	accepts := codecs.SupportedMediaTypes()
+
+	acceptsWithCBOR := make([]runtime.SerializerInfo, 0, len(accepts)+1)
+	acceptsWithCBOR = append(acceptsWithCBOR, accepts...)
+	acceptsWithCBOR = append(acceptsWithCBOR, runtime.SerializerInfo{
+		MediaType:        runtime.ContentTypeCBOR,
+		MediaTypeType:    "application",
+		MediaTypeSubType: "cbor",
+		Serializer:       cbor.NewSerializer(scheme, scheme),
+		StrictSerializer: cbor.NewSerializer(scheme, scheme, cbor.Strict(true)),
+		StreamSerializer: &runtime.StreamSerializerInfo{
+			Serializer: cbor.NewSerializer(scheme, scheme),
+			Framer:     cbor.NewFramer(),
+		},
+	})
+
+	return CodecFactory{
+		CodecFactory:    codecs,
+		accepts:         accepts,
+		acceptsWithCBOR: acceptsWithCBOR,
+		cborEnabled:     &atomic.Bool{},
+	}
+}
+
+// SetCBOREnabled sets whether application/cbor is among the supported media
+// types.
+func (f CodecFactory) SetCBOREnabled(enabled bool) {
+	f.cborEnabled.Store(enabled)
+}
+
+// SupportedMediaTypes returns the media types of the serializers of the
+// underlying serializer.CodecFactory, followed by CBOR if it is enabled.
+func (f CodecFactory) SupportedMediaTypes() []runtime.SerializerInfo {
+	if f.cborEnabled.Load() {
+		return f.acceptsWithCBOR
+	}
+	return f.accepts
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+)
+
+func TestCodecFactoryCBOR(t *testing.T) {
+	codecs := NewCodecFactory(Scheme)
+	_, ok := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), runtime.ContentTypeCBOR)
+	assert.False(t, ok, "CBOR must be disabled by default")
+
+	// copies share the setting, like the API group info and the server config
+	copied := codecs
+	codecs.SetCBOREnabled(true)
+	info, ok := runtime.SerializerInfoForMediaType(copied.SupportedMediaTypes(), runtime.ContentTypeCBOR)
+	require.True(t, ok, "CBOR must be enabled")
+
+	flunder := &v1beta1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "cbor"},
+		Spec:       v1beta1.FlunderSpec{ReferenceType: v1beta1.FlunderReferenceType, FlunderReference: "other"},
+	}
+	data, err := runtime.Encode(codecs.EncoderForVersion(info.Serializer, v1beta1.SchemeGroupVersion), flunder)
+	require.NoError(t, err)
+	obj, err := runtime.Decode(codecs.DecoderToVersion(info.Serializer, v1beta1.SchemeGroupVersion), data)
+	require.NoError(t, err)
+	require.IsType(t, &v1beta1.Flunder{}, obj)
+	assert.Equal(t, flunder.Spec, obj.(*v1beta1.Flunder).Spec)
+
+	codecs.SetCBOREnabled(false)
+	_, ok = runtime.SerializerInfoForMediaType(copied.SupportedMediaTypes(), runtime.ContentTypeCBOR)
+	assert.False(t, ok, "CBOR must be disabled again")
+}
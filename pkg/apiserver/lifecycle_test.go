@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+)
+
+// fakeStorage is a rest.Storage of new objects of a wardle type.
+type fakeStorage struct {
+	newFunc   func() runtime.Object
+	destroyed bool
+}
+
+func (s *fakeStorage) New() runtime.Object { return s.newFunc() }
+func (s *fakeStorage) Destroy()            { s.destroyed = true }
+
+func TestServedStorage(t *testing.T) {
+	for _, tc := range []struct {
+		emulationVersion string
+		expectServed     []string
+	}{
+		{emulationVersion: "1.0"},
+		{emulationVersion: "1.1", expectServed: []string{"flunders"}},
+		{emulationVersion: "1.2", expectServed: []string{"fischers", "flunders"}},
+		{emulationVersion: "1.10"},
+	} {
+		t.Run(tc.emulationVersion, func(t *testing.T) {
+			storage := map[string]*fakeStorage{
+				"flunders": {newFunc: func() runtime.Object { return &wardle.Flunder{} }},
+				"fischers": {newFunc: func() runtime.Object { return &wardle.Fischer{} }},
+			}
+
+			served, err := servedStorage(version.MustParse(tc.emulationVersion), "v1beta1", map[string]rest.Storage{
+				"flunders": storage["flunders"],
+				"fischers": storage["fischers"],
+			})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expectServed, slices.Collect(maps.Keys(served)))
+			for resource, s := range storage {
+				_, isServed := served[resource]
+				assert.Equal(t, !isServed, s.destroyed, "unserved storage of %s must be destroyed", resource)
+			}
+		})
+	}
+}
+
+func TestServedKinds(t *testing.T) {
+	for _, tc := range []struct {
+		emulationVersion string
+		obj              runtime.Object
+		expectServed     bool
+	}{
+		{emulationVersion: "1.0", obj: &v1alpha1.Flunder{}, expectServed: true},
+		{emulationVersion: "1.1", obj: &v1alpha1.FlunderQuota{}, expectServed: false},
+		{emulationVersion: "1.2", obj: &v1alpha1.FlunderQuota{}, expectServed: true},
+		{emulationVersion: "1.1", obj: &v1beta1.Fischer{}, expectServed: false},
+		{emulationVersion: "1.10", obj: &v1alpha1.Flunder{}, expectServed: false},
+	} {
+		t.Run(fmt.Sprintf("%T at %s", tc.obj, tc.emulationVersion), func(t *testing.T) {
+			servedKinds, err := ServedKinds(version.MustParse(tc.emulationVersion))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectServed, servedKinds(tc.obj))
+		})
+	}
+}
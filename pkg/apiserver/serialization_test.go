@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/streaming"
+	"k8s.io/apimachinery/pkg/watch"
+	restclientwatch "k8s.io/client-go/rest/watch"
+
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+)
+
+// benchmarkMediaTypes are the media types compared by the benchmarks.
+var benchmarkMediaTypes = []string{runtime.ContentTypeJSON, runtime.ContentTypeProtobuf}
+
+func benchmarkFlunders(n int) []v1beta1.Flunder {
+	flunders := make([]v1beta1.Flunder, n)
+	for i := range flunders {
+		flunders[i] = v1beta1.Flunder{
+			TypeMeta: metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "Flunder"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("flunder-%d", i),
+				Namespace:         "default",
+				UID:               "3e3ca9e1-5e6d-4b8a-9f2c-2f1a0a6b7c8d",
+				ResourceVersion:   fmt.Sprintf("%d", 1000+i),
+				CreationTimestamp: metav1.Unix(1700000000, 0),
+				Labels:            map[string]string{"app": "wardle", "team": "flunders"},
+			},
+			Spec: v1beta1.FlunderSpec{
+				FlunderReference: fmt.Sprintf("flunder-%d", i+1),
+				ReferenceType:    v1beta1.FlunderReferenceType,
+			},
+		}
+	}
+	return flunders
+}
+
+func serializerInfo(b *testing.B, mediaType string) runtime.SerializerInfo {
+	info, ok := runtime.SerializerInfoForMediaType(Codecs.SupportedMediaTypes(), mediaType)
+	if !ok {
+		b.Fatalf("no serializer for %s", mediaType)
+	}
+	return info
+}
+
+// BenchmarkListFlunders measures encoding and decoding a list of Flunders, as
+// done for list requests.
+func BenchmarkListFlunders(b *testing.B) {
+	list := &v1beta1.FlunderList{
+		TypeMeta: metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "FlunderList"},
+		ListMeta: metav1.ListMeta{ResourceVersion: "2000"},
+		Items:    benchmarkFlunders(500),
+	}
+
+	for _, mediaType := range benchmarkMediaTypes {
+		b.Run(mediaType, func(b *testing.B) {
+			info := serializerInfo(b, mediaType)
+			encoder := Codecs.EncoderForVersion(info.Serializer, v1beta1.SchemeGroupVersion)
+			decoder := Codecs.WithoutConversion().DecoderToVersion(info.Serializer, v1beta1.SchemeGroupVersion)
+
+			buf := &bytes.Buffer{}
+			if err := encoder.Encode(list, buf); err != nil {
+				b.Fatal(err)
+			}
+			b.SetBytes(int64(buf.Len()))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				if err := encoder.Encode(list, buf); err != nil {
+					b.Fatal(err)
+				}
+				if _, _, err := decoder.Decode(buf.Bytes(), nil, &v1beta1.FlunderList{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkWatchFlunders measures encoding and decoding a stream of Flunder
+// watch events, framed as for watch requests.
+func BenchmarkWatchFlunders(b *testing.B) {
+	flunders := benchmarkFlunders(500)
+
+	for _, mediaType := range benchmarkMediaTypes {
+		b.Run(mediaType, func(b *testing.B) {
+			info := serializerInfo(b, mediaType)
+			embeddedEncoder := Codecs.EncoderForVersion(info.Serializer, v1beta1.SchemeGroupVersion)
+			embeddedDecoder := Codecs.WithoutConversion().DecoderToVersion(info.Serializer, v1beta1.SchemeGroupVersion)
+			streamInfo := info.StreamSerializer
+
+			buf := &bytes.Buffer{}
+			encodeEvents := func() {
+				buf.Reset()
+				encoder := restclientwatch.NewEncoder(streaming.NewEncoder(streamInfo.Framer.NewFrameWriter(buf), streamInfo.Serializer), embeddedEncoder)
+				for i := range flunders {
+					if err := encoder.Encode(&watch.Event{Type: watch.Modified, Object: &flunders[i]}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			encodeEvents()
+			b.SetBytes(int64(buf.Len()))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				encodeEvents()
+				frameReader := streamInfo.Framer.NewFrameReader(io.NopCloser(bytes.NewReader(buf.Bytes())))
+				decoder := restclientwatch.NewDecoder(streaming.NewDecoder(frameReader, streamInfo.Serializer), embeddedDecoder)
+				for range flunders {
+					if _, _, err := decoder.Decode(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package banning decides which Fischers and FlunderPolicies ban a Flunder. It
+// is shared by the BanFlunder admission plugin and the FlunderBanReview API, so
+// that both always give the same answer.
+//
+// The precedence is:
+//
+//  1. A Fischer bans the names it disallows in all namespaces. Its bans cannot
+//     be overridden.
+//  2. A FlunderPolicy bans the names it disallows in its namespace, unless any
+//     FlunderPolicy of the namespace allows the name.
+//  3. All other names are allowed.
+//
+// Allowed names which are close to an entry of a Fischer are near misses. The
+// Flunder registry returns them as warnings.
+package banning
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+)
+
+// Attributes are the attributes of a Flunder that are relevant for banning.
+type Attributes struct {
+	Name      string
+	Namespace string
+}
+
+// Ban is a Fischer or FlunderPolicy entry that bans a Flunder.
+type Ban struct {
+	// Fischer is the name of the Fischer holding the entry.
+	Fischer string
+	// FlunderPolicy is the name of the FlunderPolicy in the namespace of the
+	// Flunder holding the entry.
+	FlunderPolicy string
+	// Entry is the entry that matches the Flunder.
+	Entry string
+}
+
+// Bans returns the entries of the given Fischers and FlunderPolicies which ban
+// a Flunder with the given attributes. Bans of Fischers come first, sorted by
+// Fischer name, followed by bans of FlunderPolicies, sorted by policy name.
+// FlunderPolicies of other namespaces than the one of the Flunder are ignored.
+// The Flunder may be created if the result is empty.
+func Bans(fischers []*v1alpha1.Fischer, policies []*v1alpha1.FlunderPolicy, attrs Attributes) []Ban {
+	var bans []Ban
+	for _, fischer := range fischers {
+		for _, disallowedFlunder := range fischer.DisallowedFlunders {
+			if attrs.Name == disallowedFlunder {
+				bans = append(bans, Ban{Fischer: fischer.Name, Entry: disallowedFlunder})
+			}
+		}
+	}
+	sort.SliceStable(bans, func(i, j int) bool {
+		return bans[i].Fischer < bans[j].Fischer
+	})
+
+	var policyBans []Ban
+	for _, policy := range policies {
+		if policy.Namespace != attrs.Namespace {
+			continue
+		}
+		for _, allowedFlunder := range policy.Spec.AllowedFlunders {
+			if attrs.Name == allowedFlunder {
+				return bans
+			}
+		}
+		for _, disallowedFlunder := range policy.Spec.DisallowedFlunders {
+			if attrs.Name == disallowedFlunder {
+				policyBans = append(policyBans, Ban{FlunderPolicy: policy.Name, Entry: disallowedFlunder})
+			}
+		}
+	}
+	sort.SliceStable(policyBans, func(i, j int) bool {
+		return policyBans[i].FlunderPolicy < policyBans[j].FlunderPolicy
+	})
+	return append(bans, policyBans...)
+}
+
+// NearMiss is a Fischer entry that is close to the name of a Flunder without
+// banning it.
+type NearMiss struct {
+	// Fischer is the name of the Fischer holding the entry.
+	Fischer string
+	// Entry is the entry that is close to the name of the Flunder.
+	Entry string
+	// Distance is the edit distance between the name and the entry, ignoring
+	// case. It is 0 if they differ only in case.
+	Distance int
+}
+
+// NearMisses returns the entries of the given Fischers which are close to the
+// name in attrs without being equal to it, sorted by Fischer name. An entry is
+// close if it is within the NearMissDistance of its Fischer, ignoring case.
+// Fischers without a NearMissDistance are ignored.
+func NearMisses(fischers []*v1alpha1.Fischer, attrs Attributes) []NearMiss {
+	var nearMisses []NearMiss
+	name := strings.ToLower(attrs.Name)
+	for _, fischer := range fischers {
+		if fischer.NearMissDistance == nil {
+			continue
+		}
+		for _, disallowedFlunder := range fischer.DisallowedFlunders {
+			if attrs.Name == disallowedFlunder {
+				continue
+			}
+			distance := editDistance(name, strings.ToLower(disallowedFlunder))
+			if distance <= int(*fischer.NearMissDistance) {
+				nearMisses = append(nearMisses, NearMiss{Fischer: fischer.Name, Entry: disallowedFlunder, Distance: distance})
+			}
+		}
+	}
+	sort.SliceStable(nearMisses, func(i, j int) bool {
+		return nearMisses[i].Fischer < nearMisses[j].Fischer
+	})
+	return nearMisses
+}
+
+// Reference is a Flunder referencing another Flunder of its namespace.
+type Reference struct {
+	Flunder *v1alpha1.Flunder
+	Target  *v1alpha1.Flunder
+}
+
+// Impact is the effect of changing the entries of a Fischer on existing
+// Flunders.
+type Impact struct {
+	// Banned are the Flunders which the Fischer bans after the change but not
+	// before, sorted by namespace and name.
+	Banned []*v1alpha1.Flunder
+	// Referencing are the Flunders which reference a Flunder in Banned, sorted
+	// by namespace and name of the referencing Flunder.
+	Referencing []Reference
+}
+
+// WhatIf returns the impact of replacing the Fischer old, which is nil if it
+// does not exist yet, by updated on the given Flunders.
+func WhatIf(old, updated *v1alpha1.Fischer, flunders []*v1alpha1.Flunder) Impact {
+	impact := Impact{}
+	banned := map[types.NamespacedName]*v1alpha1.Flunder{}
+	for _, flunder := range flunders {
+		attrs := Attributes{Name: flunder.Name, Namespace: flunder.Namespace}
+		if len(Bans([]*v1alpha1.Fischer{updated}, nil, attrs)) == 0 {
+			continue
+		}
+		if old != nil && len(Bans([]*v1alpha1.Fischer{old}, nil, attrs)) != 0 {
+			continue
+		}
+		impact.Banned = append(impact.Banned, flunder)
+		banned[types.NamespacedName{Namespace: flunder.Namespace, Name: flunder.Name}] = flunder
+	}
+
+	for _, flunder := range flunders {
+		if flunder.Spec.ReferenceType != nil && *flunder.Spec.ReferenceType != v1alpha1.FlunderReferenceType {
+			continue
+		}
+		if target, found := banned[types.NamespacedName{Namespace: flunder.Namespace, Name: flunder.Spec.Reference}]; found {
+			impact.Referencing = append(impact.Referencing, Reference{Flunder: flunder, Target: target})
+		}
+	}
+
+	sort.Slice(impact.Banned, func(i, j int) bool {
+		return lessFlunder(impact.Banned[i], impact.Banned[j])
+	})
+	sort.Slice(impact.Referencing, func(i, j int) bool {
+		return lessFlunder(impact.Referencing[i].Flunder, impact.Referencing[j].Flunder)
+	})
+	return impact
+}
+
+func lessFlunder(a, b *v1alpha1.Flunder) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	previous := make([]int, len(t)+1)
+	current := make([]int, len(t)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		current[0] = i
+		for j := 1; j <= len(t); j++ {
+			substitution := previous[j-1]
+			if s[i-1] != t[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(t)]
+}
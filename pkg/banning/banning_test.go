@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package banning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+)
+
+func TestBans(t *testing.T) {
+	fischers := []*v1alpha1.Fischer{
+		{ObjectMeta: metav1.ObjectMeta{Name: "second"}, DisallowedFlunders: []string{"badname", "worsename"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "first"}, DisallowedFlunders: []string{"badname"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "empty"}},
+	}
+
+	testCases := []struct {
+		desc     string
+		attrs    Attributes
+		expected []Ban
+	}{
+		{
+			desc:  "banned by several fischers",
+			attrs: Attributes{Name: "badname", Namespace: "default"},
+			expected: []Ban{
+				{Fischer: "first", Entry: "badname"},
+				{Fischer: "second", Entry: "badname"},
+			},
+		},
+		{
+			desc:     "banned by one fischer",
+			attrs:    Attributes{Name: "worsename"},
+			expected: []Ban{{Fischer: "second", Entry: "worsename"}},
+		},
+		{
+			desc:  "not banned",
+			attrs: Attributes{Name: "goodname"},
+		},
+		{
+			desc:  "prefix is not banned",
+			attrs: Attributes{Name: "badname-2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Bans(fischers, nil, tc.attrs))
+		})
+	}
+}
+
+func TestBansWithPolicies(t *testing.T) {
+	fischers := []*v1alpha1.Fischer{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}, DisallowedFlunders: []string{"clusterbanned"}},
+	}
+	policies := []*v1alpha1.FlunderPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "strict", Namespace: "team"},
+			Spec:       v1alpha1.FlunderPolicySpec{DisallowedFlunders: []string{"badname", "exception", "clusterbanned"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "lax", Namespace: "team"},
+			Spec:       v1alpha1.FlunderPolicySpec{DisallowedFlunders: []string{"badname"}, AllowedFlunders: []string{"exception", "clusterbanned"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"},
+			Spec:       v1alpha1.FlunderPolicySpec{DisallowedFlunders: []string{"othername"}},
+		},
+	}
+
+	testCases := []struct {
+		desc     string
+		attrs    Attributes
+		expected []Ban
+	}{
+		{
+			desc:  "banned by several policies",
+			attrs: Attributes{Name: "badname", Namespace: "team"},
+			expected: []Ban{
+				{FlunderPolicy: "lax", Entry: "badname"},
+				{FlunderPolicy: "strict", Entry: "badname"},
+			},
+		},
+		{
+			desc:  "allowed by another policy",
+			attrs: Attributes{Name: "exception", Namespace: "team"},
+		},
+		{
+			desc:     "fischer bans cannot be allowed",
+			attrs:    Attributes{Name: "clusterbanned", Namespace: "team"},
+			expected: []Ban{{Fischer: "cluster", Entry: "clusterbanned"}},
+		},
+		{
+			desc:  "policies of other namespaces are ignored",
+			attrs: Attributes{Name: "othername", Namespace: "team"},
+		},
+		{
+			desc:  "policies are ignored without namespace",
+			attrs: Attributes{Name: "badname"},
+		},
+		{
+			desc:     "banned in the namespace of the policy",
+			attrs:    Attributes{Name: "othername", Namespace: "other"},
+			expected: []Ban{{FlunderPolicy: "other", Entry: "othername"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Bans(fischers, policies, tc.attrs))
+		})
+	}
+}
+
+func TestNearMisses(t *testing.T) {
+	one, two := int32(1), int32(2)
+	fischers := []*v1alpha1.Fischer{
+		{ObjectMeta: metav1.ObjectMeta{Name: "strict"}, DisallowedFlunders: []string{"badname"}, NearMissDistance: &two},
+		{ObjectMeta: metav1.ObjectMeta{Name: "loose"}, DisallowedFlunders: []string{"badname", "other"}, NearMissDistance: &one},
+		{ObjectMeta: metav1.ObjectMeta{Name: "disabled"}, DisallowedFlunders: []string{"badname"}},
+	}
+
+	testCases := []struct {
+		desc     string
+		name     string
+		expected []NearMiss
+	}{
+		{
+			desc: "differs in case",
+			name: "BadName",
+			expected: []NearMiss{
+				{Fischer: "loose", Entry: "badname"},
+				{Fischer: "strict", Entry: "badname"},
+			},
+		},
+		{
+			desc: "within the distance of one fischer",
+			name: "bdname2",
+			expected: []NearMiss{
+				{Fischer: "strict", Entry: "badname", Distance: 2},
+			},
+		},
+		{
+			desc: "banned names are no near misses",
+			name: "badname",
+		},
+		{
+			desc: "far away",
+			name: "goodname",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NearMisses(fischers, Attributes{Name: tc.name}))
+		})
+	}
+}
+
+func TestWhatIf(t *testing.T) {
+	flunderRef, fischerRef := v1alpha1.FlunderReferenceType, v1alpha1.FischerReferenceType
+	flunder := func(namespace, name, reference string, referenceType *v1alpha1.ReferenceType) *v1alpha1.Flunder {
+		return &v1alpha1.Flunder{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       v1alpha1.FlunderSpec{Reference: reference, ReferenceType: referenceType},
+		}
+	}
+	oldBanned := flunder("a", "old", "", nil)
+	newBannedA := flunder("a", "new", "", nil)
+	newBannedB := flunder("b", "new", "", nil)
+	referencing := flunder("a", "referencing", "new", &flunderRef)
+	defaulted := flunder("b", "defaulted", "new", nil)
+	fischerReferencing := flunder("a", "fischer", "new", &fischerRef)
+	otherNamespace := flunder("c", "other", "new", &flunderRef)
+	flunders := []*v1alpha1.Flunder{referencing, newBannedB, oldBanned, fischerReferencing, newBannedA, defaulted, otherNamespace}
+
+	old := &v1alpha1.Fischer{ObjectMeta: metav1.ObjectMeta{Name: "fischer"}, DisallowedFlunders: []string{"old"}}
+	updated := &v1alpha1.Fischer{ObjectMeta: metav1.ObjectMeta{Name: "fischer"}, DisallowedFlunders: []string{"old", "new"}}
+
+	assert.Equal(t, Impact{
+		Banned: []*v1alpha1.Flunder{newBannedA, newBannedB},
+		Referencing: []Reference{
+			{Flunder: referencing, Target: newBannedA},
+			{Flunder: defaulted, Target: newBannedB},
+		},
+	}, WhatIf(old, updated, flunders))
+
+	assert.Equal(t, []*v1alpha1.Flunder{newBannedA, oldBanned, newBannedB}, WhatIf(nil, updated, flunders).Banned, "all Flunders are new to a new Fischer")
+	assert.Empty(t, WhatIf(updated, old, flunders), "removing entries bans nothing")
+}
+
+func TestEditDistance(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"flunder", "flunder", 0},
+		{"flunder", "flundre", 2},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, editDistance(tc.a, tc.b), "%q and %q", tc.a, tc.b)
+		assert.Equal(t, tc.expected, editDistance(tc.b, tc.a), "%q and %q", tc.b, tc.a)
+	}
+}
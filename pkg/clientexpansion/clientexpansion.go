@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clientexpansion implements the hand-written methods of the typed
+// wardle clients. The methods only use the generated methods of the clients, so
+// that the real and the fake clients share the same implementation.
+package clientexpansion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/retry"
+)
+
+// Object is a typed API object.
+type Object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// Getter gets objects of one resource by name.
+type Getter[T Object] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+}
+
+// Updater gets and updates objects of one resource.
+type Updater[T Object] interface {
+	Getter[T]
+	Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
+}
+
+// Watcher gets and watches objects of one resource.
+type Watcher[T Object] interface {
+	Getter[T]
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+}
+
+// ResolveChain returns the named object followed by the objects it references
+// transitively. next returns the name of the object referenced by an object,
+// or an empty name at the end of the chain.
+//
+// If a referenced object cannot be retrieved, the chain up to the referencing
+// object is returned with the error. A reference cycle is an error.
+func ResolveChain[T Object](ctx context.Context, c Getter[T], name string, next func(T) string) ([]T, error) {
+	var chain []T
+	names := []string{}
+	for len(name) != 0 {
+		for _, seen := range names {
+			if seen == name {
+				return chain, fmt.Errorf("reference cycle: %s -> %s", strings.Join(names, " -> "), name)
+			}
+		}
+
+		obj, err := c.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if len(chain) == 0 {
+				return nil, err
+			}
+			return chain, fmt.Errorf("failed to resolve reference of %q: %w", names[len(names)-1], err)
+		}
+		chain = append(chain, obj)
+		names = append(names, name)
+		name = next(obj)
+	}
+	return chain, nil
+}
+
+// Update gets the named object, lets mutate change it and updates it. On
+// conflicts, it starts over with the latest object. If mutate returns false,
+// the object is returned without an update.
+func Update[T Object](ctx context.Context, c Updater[T], name string, mutate func(T) bool) (T, error) {
+	var result T
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := c.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !mutate(obj) {
+			result = obj
+			return nil
+		}
+		result, err = c.Update(ctx, obj, metav1.UpdateOptions{})
+		return err
+	})
+	return result, err
+}
+
+// WaitFor watches the named object of the given resource until condition
+// returns true or an error, and returns the last seen object. It fails with a
+// NotFound error if the object does not exist or is deleted, and with the error
+// of the context when it is done.
+func WaitFor[T Object](ctx context.Context, c Watcher[T], resource schema.GroupResource, name string, condition func(T) (bool, error)) (T, error) {
+	var zero T
+	for {
+		obj, err := c.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return zero, err
+		}
+		if done, err := condition(obj); err != nil || done {
+			return obj, err
+		}
+
+		w, err := c.Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+			ResourceVersion: obj.GetResourceVersion(),
+		})
+		if err != nil {
+			return zero, err
+		}
+		obj, done, err := watchUntil(ctx, w, resource, name, condition)
+		w.Stop()
+		if err != nil || done {
+			return obj, err
+		}
+		// the watch ended or expired, start over with the latest object
+	}
+}
+
+// watchUntil returns false without an error if the watch ended before the
+// condition was met.
+func watchUntil[T Object](ctx context.Context, w watch.Interface, resource schema.GroupResource, name string, condition func(T) (bool, error)) (T, bool, error) {
+	var zero T
+	for {
+		select {
+		case <-ctx.Done():
+			return zero, false, ctx.Err()
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return zero, false, nil
+			}
+			switch event.Type {
+			case watch.Error:
+				err := apierrors.FromObject(event.Object)
+				if status, ok := err.(apierrors.APIStatus); ok && status.Status().Code == http.StatusGone {
+					return zero, false, nil
+				}
+				return zero, false, err
+			case watch.Added, watch.Modified, watch.Deleted:
+				obj, ok := event.Object.(T)
+				// fake clients ignore field selectors
+				if !ok || obj.GetName() != name {
+					continue
+				}
+				if event.Type == watch.Deleted {
+					return zero, false, apierrors.NewNotFound(resource, name)
+				}
+				done, err := condition(obj)
+				if err != nil || done {
+					return obj, done, err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientexpansion_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/fake"
+)
+
+func flunder(name string, referenceType v1beta1.ReferenceType, reference string) *v1beta1.Flunder {
+	f := &v1beta1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec:       v1beta1.FlunderSpec{ReferenceType: referenceType},
+	}
+	switch referenceType {
+	case v1beta1.FlunderReferenceType:
+		f.Spec.FlunderReference = reference
+	case v1beta1.FischerReferenceType:
+		f.Spec.FischerReference = reference
+	}
+	return f
+}
+
+func names[T metav1.Object](objs []T) []string {
+	var result []string
+	for _, obj := range objs {
+		result = append(result, obj.GetName())
+	}
+	return result
+}
+
+func TestResolveReference(t *testing.T) {
+	ctx := context.Background()
+	cs := fake.NewSimpleClientset(
+		flunder("a", v1beta1.FlunderReferenceType, "b"),
+		flunder("b", v1beta1.FlunderReferenceType, "c"),
+		flunder("c", v1beta1.FischerReferenceType, "fischer"),
+		flunder("cycle-a", v1beta1.FlunderReferenceType, "cycle-b"),
+		flunder("cycle-b", v1beta1.FlunderReferenceType, "cycle-a"),
+		flunder("dangling", v1beta1.FlunderReferenceType, "missing"),
+	)
+	flunders := cs.WardleV1beta1().Flunders("ns")
+
+	chain, err := flunders.ResolveReference(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, names(chain))
+
+	chain, err = flunders.ResolveReference(ctx, "c")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, names(chain))
+
+	_, err = flunders.ResolveReference(ctx, "cycle-a")
+	assert.EqualError(t, err, "reference cycle: cycle-a -> cycle-b -> cycle-a")
+
+	chain, err = flunders.ResolveReference(ctx, "dangling")
+	assert.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+	assert.Equal(t, []string{"dangling"}, names(chain))
+
+	_, err = flunders.ResolveReference(ctx, "missing")
+	assert.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+
+	// v1alpha1 references without a type are Flunder references
+	fischerReference := v1alpha1.FischerReferenceType
+	cs = fake.NewSimpleClientset(
+		&v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns"}, Spec: v1alpha1.FlunderSpec{Reference: "b"}},
+		&v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns"}, Spec: v1alpha1.FlunderSpec{Reference: "fischer", ReferenceType: &fischerReference}},
+	)
+	alphaChain, err := cs.WardleV1alpha1().Flunders("ns").ResolveReference(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, names(alphaChain))
+}
+
+func TestBanName(t *testing.T) {
+	ctx := context.Background()
+	cs := fake.NewSimpleClientset(&v1alpha1.Fischer{
+		ObjectMeta:         metav1.ObjectMeta{Name: "fischer"},
+		DisallowedFlunders: []string{"a"},
+	})
+	conflicts := 1
+	cs.PrependReactor("update", "fischers", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if conflicts == 0 {
+			return false, nil, nil
+		}
+		conflicts--
+		return true, nil, apierrors.NewConflict(v1alpha1.Resource("fischers"), "fischer", nil)
+	})
+	fischers := cs.WardleV1alpha1().Fischers()
+
+	fischer, err := fischers.BanName(ctx, "fischer", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, fischer.DisallowedFlunders)
+	assert.Zero(t, conflicts, "the conflict must be retried")
+
+	updates := len(cs.Actions())
+	fischer, err = fischers.BanName(ctx, "fischer", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, fischer.DisallowedFlunders)
+	for _, action := range cs.Actions()[updates:] {
+		assert.NotEqual(t, "update", action.GetVerb(), "banning a banned name must not update")
+	}
+
+	fischer, err = fischers.UnbanName(ctx, "fischer", "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, fischer.DisallowedFlunders)
+
+	stored, err := fischers.Get(ctx, "fischer", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, stored.DisallowedFlunders)
+
+	_, err = fischers.BanName(ctx, "missing", "b")
+	assert.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+}
+
+func TestWaitForCondition(t *testing.T) {
+	labeled := func(f *v1beta1.Flunder) (bool, error) {
+		return f.Labels["ready"] == "true", nil
+	}
+
+	t.Run("met", func(t *testing.T) {
+		f := flunder("flunder", "", "")
+		f.Labels = map[string]string{"ready": "true"}
+		cs := fake.NewSimpleClientset(f)
+		got, err := cs.WardleV1beta1().Flunders("ns").WaitForCondition(context.Background(), "flunder", labeled)
+		require.NoError(t, err)
+		assert.Equal(t, "flunder", got.Name)
+	})
+
+	t.Run("updated", func(t *testing.T) {
+		cs := fake.NewSimpleClientset(flunder("flunder", "", ""), flunder("other", "", ""))
+		watching := watchStarted(cs)
+		flunders := cs.WardleV1beta1().Flunders("ns")
+
+		done := make(chan error)
+		go func() {
+			_, err := flunders.WaitForCondition(context.Background(), "flunder", labeled)
+			done <- err
+		}()
+		<-watching
+
+		other := flunder("other", "", "")
+		other.Labels = map[string]string{"ready": "true"}
+		_, err := flunders.Update(context.Background(), other, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		updated := flunder("flunder", "", "")
+		updated.Labels = map[string]string{"ready": "true"}
+		_, err = flunders.Update(context.Background(), updated, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatal("condition was not met")
+		}
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		cs := fake.NewSimpleClientset(flunder("flunder", "", ""))
+		watching := watchStarted(cs)
+		flunders := cs.WardleV1beta1().Flunders("ns")
+
+		done := make(chan error)
+		go func() {
+			_, err := flunders.WaitForCondition(context.Background(), "flunder", labeled)
+			done <- err
+		}()
+		<-watching
+		require.NoError(t, flunders.Delete(context.Background(), "flunder", metav1.DeleteOptions{}))
+
+		select {
+		case err := <-done:
+			assert.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatal("deletion was not noticed")
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		cs := fake.NewSimpleClientset(flunder("flunder", "", ""))
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := cs.WardleV1beta1().Flunders("ns").WaitForCondition(ctx, "flunder", labeled)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// watchStarted returns a channel which is closed once a watch of the fake
+// clientset is set up. The fake clientset handles one action at a time, so
+// later actions are seen by the watch.
+func watchStarted(cs *fake.Clientset) <-chan struct{} {
+	started := make(chan struct{})
+	watchReactor := cs.WatchReactionChain[0]
+	cs.PrependWatchReactor("*", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		handled, w, err := watchReactor.React(action)
+		close(started)
+		return handled, w, err
+	})
+	return started
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdgen
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apiserver"
+	"k8s.io/sample-apiserver/pkg/crd"
+	sampleopenapi "k8s.io/sample-apiserver/pkg/generated/openapi"
+)
+
+const header = "# Code generated by crd-gen. DO NOT EDIT.\n"
+
+var ageColumn = crd.CustomResourceColumnDefinition{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"}
+
+// Resources are the stored wardle resources with the versions they are served
+// in by the wardle server. FlunderBanReviews are not stored and have no
+// CustomResourceDefinition equivalent.
+var Resources = []crd.Resource{
+	{
+		Group:      wardle.GroupName,
+		Kind:       "Fischer",
+		Plural:     "fischers",
+		Singular:   "fischer",
+		Namespaced: false,
+		Versions: []crd.Version{
+			{
+				Name: "v1alpha1",
+				PrinterColumns: []crd.CustomResourceColumnDefinition{
+					{Name: "Disallowed Flunders", Type: "string", JSONPath: ".disallowedFlunders"},
+					ageColumn,
+				},
+			},
+			{
+				Name: "v1beta1",
+				PrinterColumns: []crd.CustomResourceColumnDefinition{
+					{Name: "Disallowed Flunders", Type: "string", JSONPath: ".disallowedFlunders"},
+					ageColumn,
+				},
+			},
+		},
+		// the wardle server encodes all versions as v1alpha1 in etcd
+		StorageVersion: "v1alpha1",
+	},
+	{
+		Group:      wardle.GroupName,
+		Kind:       "Flunder",
+		Plural:     "flunders",
+		Singular:   "flunder",
+		Namespaced: true,
+		Versions: []crd.Version{
+			{
+				Name: "v1alpha1",
+				PrinterColumns: []crd.CustomResourceColumnDefinition{
+					{Name: "Reference Type", Type: "string", JSONPath: ".spec.referenceType"},
+					{Name: "Reference", Type: "string", JSONPath: ".spec.reference"},
+					ageColumn,
+				},
+			},
+			{
+				Name: "v1beta1",
+				PrinterColumns: []crd.CustomResourceColumnDefinition{
+					{Name: "Reference Type", Type: "string", JSONPath: ".spec.referenceType"},
+					{Name: "Flunder Reference", Type: "string", JSONPath: ".spec.flunderReference"},
+					{Name: "Fischer Reference", Type: "string", JSONPath: ".spec.fischerReference"},
+					ageColumn,
+				},
+			},
+		},
+		// the wardle server encodes all versions as v1alpha1 in etcd
+		StorageVersion: "v1alpha1",
+	},
+	{
+		Group:      wardle.GroupName,
+		Kind:       "FlunderPolicy",
+		Plural:     "flunderpolicies",
+		Singular:   "flunderpolicy",
+		Namespaced: true,
+		Versions: []crd.Version{
+			{
+				Name: "v1alpha1",
+				PrinterColumns: []crd.CustomResourceColumnDefinition{
+					{Name: "Disallowed Flunders", Type: "string", JSONPath: ".spec.disallowedFlunders"},
+					{Name: "Allowed Flunders", Type: "string", JSONPath: ".spec.allowedFlunders"},
+					ageColumn,
+				},
+			},
+		},
+		StorageVersion: "v1alpha1",
+	},
+	{
+		Group:      wardle.GroupName,
+		Kind:       "FlunderQuota",
+		Plural:     "flunderquotas",
+		Singular:   "flunderquota",
+		Namespaced: true,
+		Versions: []crd.Version{
+			{
+				Name: "v1alpha1",
+				PrinterColumns: []crd.CustomResourceColumnDefinition{
+					{Name: "Hard", Type: "string", JSONPath: ".status.hard"},
+					{Name: "Used", Type: "string", JSONPath: ".status.used"},
+					ageColumn,
+				},
+			},
+		},
+		StorageVersion: "v1alpha1",
+		// the controller writes the status through flunderquotas/status
+		StatusSubresource: true,
+	},
+}
+
+// CRDGenOptions contains the options of the CustomResourceDefinition generator.
+type CRDGenOptions struct {
+	// OutputDir is the directory the manifests are written to, one file per
+	// resource. If it is empty, all manifests are written to StdOut.
+	OutputDir string
+
+	// WebhookServiceNamespace and WebhookServiceName reference the service of
+	// the wardle server which serves the conversion webhook.
+	WebhookServiceNamespace string
+	WebhookServiceName      string
+	// WebhookURL is the URL of the conversion webhook. It is used instead of
+	// the service if it is set.
+	WebhookURL string
+	// CABundleFile is the file with the PEM encoded CA bundle used to verify
+	// the certificate of the conversion webhook.
+	CABundleFile string
+
+	StdOut io.Writer
+}
+
+// NewCRDGenOptions returns the default options, which reference the service
+// of the example deployment in artifacts/example.
+func NewCRDGenOptions(out io.Writer) *CRDGenOptions {
+	return &CRDGenOptions{
+		WebhookServiceNamespace: "wardle",
+		WebhookServiceName:      "api",
+		StdOut:                  out,
+	}
+}
+
+// NewCommandGenerateCRDs provides a CLI handler for the CustomResourceDefinition generator.
+func NewCommandGenerateCRDs(defaults *CRDGenOptions) *cobra.Command {
+	o := *defaults
+	cmd := &cobra.Command{
+		Use:   "crd-gen",
+		Short: "Generate CustomResourceDefinitions equivalent to the wardle API",
+		Long: "Generate structural CustomResourceDefinitions for every stored wardle kind in all served " +
+			"versions. Conversions between the versions are delegated to the conversion webhook of " +
+			"the wardle server, which must run with --enable-conversion-webhook.",
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&o.OutputDir, "output-dir", o.OutputDir, "The directory to write one manifest per resource to. Defaults to standard output.")
+	flags.StringVar(&o.WebhookServiceNamespace, "webhook-service-namespace", o.WebhookServiceNamespace, "The namespace of the service of the conversion webhook.")
+	flags.StringVar(&o.WebhookServiceName, "webhook-service-name", o.WebhookServiceName, "The name of the service of the conversion webhook.")
+	flags.StringVar(&o.WebhookURL, "webhook-url", o.WebhookURL, "The URL of the conversion webhook. Takes precedence over the service.")
+	flags.StringVar(&o.CABundleFile, "ca-bundle-file", o.CABundleFile, "A file with the PEM encoded CA bundle to verify the conversion webhook with.")
+
+	return cmd
+}
+
+// Validate validates CRDGenOptions
+func (o CRDGenOptions) Validate() error {
+	if len(o.WebhookURL) == 0 && (len(o.WebhookServiceNamespace) == 0 || len(o.WebhookServiceName) == 0) {
+		return fmt.Errorf("either --webhook-url or --webhook-service-namespace and --webhook-service-name are required")
+	}
+	return nil
+}
+
+// Run generates the manifests and writes them to OutputDir or StdOut.
+func (o CRDGenOptions) Run() error {
+	manifests, err := o.Generate()
+	if err != nil {
+		return err
+	}
+
+	if len(o.OutputDir) == 0 {
+		for i, m := range manifests {
+			if i > 0 {
+				if _, err := io.WriteString(o.StdOut, "---\n"); err != nil {
+					return err
+				}
+			}
+			if _, err := o.StdOut.Write(m.Data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(o.OutputDir, 0755); err != nil {
+		return err
+	}
+	for _, m := range manifests {
+		if err := os.WriteFile(filepath.Join(o.OutputDir, m.FileName), m.Data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Manifest is a serialized CustomResourceDefinition.
+type Manifest struct {
+	FileName string
+	Data     []byte
+}
+
+// Generate returns the manifests of the CustomResourceDefinitions of all
+// Resources, in the order of Resources.
+func (o CRDGenOptions) Generate() ([]Manifest, error) {
+	generator := crd.NewGenerator(sampleopenapi.GetOpenAPIDefinitions, apiserver.Scheme)
+	generator.Webhook = &crd.WebhookClientConfig{}
+	if len(o.WebhookURL) != 0 {
+		url := o.WebhookURL
+		generator.Webhook.URL = &url
+	} else {
+		generator.Webhook.Service = &crd.ServiceReference{
+			Namespace: o.WebhookServiceNamespace,
+			Name:      o.WebhookServiceName,
+		}
+	}
+	if len(o.CABundleFile) != 0 {
+		caBundle, err := os.ReadFile(o.CABundleFile)
+		if err != nil {
+			return nil, err
+		}
+		generator.Webhook.CABundle = caBundle
+	}
+
+	var manifests []Manifest
+	for _, r := range Resources {
+		definition, err := generator.Generate(r)
+		if err != nil {
+			return nil, err
+		}
+		data, err := yaml.Marshal(definition)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, Manifest{
+			FileName: r.Group + "_" + r.Plural + ".yaml",
+			Data:     append([]byte(header), data...),
+		})
+	}
+	return manifests, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdgen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratedManifestsAreUpToDate compares the output with the manifests in
+// artifacts/crds, which are updated by hack/update-codegen.sh.
+func TestGeneratedManifestsAreUpToDate(t *testing.T) {
+	manifests, err := NewCRDGenOptions(nil).Generate()
+	require.NoError(t, err)
+
+	dir := filepath.Join("..", "..", "..", "artifacts", "crds")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, len(manifests), "artifacts/crds has stale manifests, run hack/update-codegen.sh")
+
+	for _, m := range manifests {
+		expected, err := os.ReadFile(filepath.Join(dir, m.FileName))
+		require.NoError(t, err)
+		if diff := cmp.Diff(string(expected), string(m.Data)); len(diff) != 0 {
+			t.Errorf("%s is out of date, run hack/update-codegen.sh (-want +got):\n%s", m.FileName, diff)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	caBundle := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caBundle, []byte("ca"), 0644))
+
+	out := &bytes.Buffer{}
+	o := NewCRDGenOptions(out)
+	o.WebhookURL = "https://wardle.example.com/convert"
+	o.CABundleFile = caBundle
+	require.NoError(t, o.Validate())
+	require.NoError(t, o.Run())
+
+	documents := strings.Split(out.String(), "---\n")
+	require.Len(t, documents, len(Resources))
+	assert.Contains(t, documents[1], "url: https://wardle.example.com/convert")
+	assert.Contains(t, documents[1], "caBundle: Y2E=")
+	assert.NotContains(t, documents[1], "service:")
+
+	dir := filepath.Join(t.TempDir(), "crds")
+	o.OutputDir = dir
+	require.NoError(t, o.Run())
+	for _, r := range Resources {
+		assert.FileExists(t, filepath.Join(dir, r.Group+"_"+r.Plural+".yaml"))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	o := NewCRDGenOptions(nil)
+	o.WebhookServiceName = ""
+	assert.Error(t, o.Validate())
+
+	o.WebhookURL = "https://wardle.example.com/convert"
+	assert.NoError(t, o.Validate())
+}
@@ -0,0 +1,955 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/cbor"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/banflunder"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+	servertesting "k8s.io/sample-apiserver/pkg/cmd/server/testing"
+	"k8s.io/sample-apiserver/pkg/conversionwebhook"
+	applyv1alpha1 "k8s.io/sample-apiserver/pkg/generated/applyconfiguration/wardle/v1alpha1"
+	applyv1beta1 "k8s.io/sample-apiserver/pkg/generated/applyconfiguration/wardle/v1beta1"
+	clientset "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
+	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/scheme"
+)
+
+func TestWardleServer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	admissionConfig := filepath.Join(t.TempDir(), "admission.yaml")
+	require.NoError(t, os.WriteFile(admissionConfig, []byte(`
+apiVersion: apiserver.config.k8s.io/v1
+kind: AdmissionConfiguration
+plugins:
+- name: CELValidation
+  configuration:
+    rules:
+    - kind: Flunder
+      expression: "object.metadata.namespace != 'cel' || object.metadata.name.startsWith('team-')"
+      message: flunder names in namespace cel must start with team-
+`), 0600))
+
+	server := servertesting.StartTestServerOrDie(t, []string{
+		"--enable-conversion-webhook",
+		"--admission-control-config-file=" + admissionConfig,
+		"--feature-gates=wardle:CBORServing=true",
+	})
+	defer server.TearDownFn()
+
+	t.Run("CRUD", func(t *testing.T) { testCRUD(t, server.ClientSet) })
+	t.Run("Watch", func(t *testing.T) { testWatch(t, server.ClientSet) })
+	t.Run("FlunderDefaults", func(t *testing.T) { testFlunderDefaults(t, server.ClientSet) })
+	t.Run("BanFlunder", func(t *testing.T) { testBanFlunder(t, server.ClientSet) })
+	t.Run("NearMissWarnings", func(t *testing.T) { testNearMissWarnings(t, server.ClientConfig) })
+	t.Run("FischerWhatIf", func(t *testing.T) { testFischerWhatIf(t, server.ClientConfig) })
+	t.Run("FlunderPolicy", func(t *testing.T) { testFlunderPolicy(t, server.ClientSet) })
+	t.Run("Ownership", func(t *testing.T) { testOwnership(t, server.ClientConfig) })
+	t.Run("FlunderQuota", func(t *testing.T) { testFlunderQuota(t, server.ClientSet) })
+	t.Run("CELValidation", func(t *testing.T) { testCELValidation(t, server.ClientSet) })
+	t.Run("VersionConversion", func(t *testing.T) { testVersionConversion(t, server.ClientSet) })
+	t.Run("Generation", func(t *testing.T) { testGeneration(t, server.ClientSet) })
+	t.Run("Protobuf", func(t *testing.T) { testProtobuf(t, server.ClientConfig) })
+	t.Run("CBOR", func(t *testing.T) { testCBOR(t, server.ClientConfig) })
+	t.Run("ConversionWebhook", func(t *testing.T) { testConversionWebhook(t, server.ClientSet) })
+	t.Run("ServerSideApply", func(t *testing.T) { testServerSideApply(t, server.ClientSet) })
+	t.Run("ManagedFieldsUpgrade", func(t *testing.T) { testManagedFieldsUpgrade(t, server.ClientSet) })
+}
+
+func TestWardleServerEmulatedVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	server := servertesting.StartTestServerOrDie(t, []string{"--emulated-version=wardle=1.1"})
+	defer server.TearDownFn()
+	ctx := context.Background()
+
+	// the kinds introduced in 1.2 are not served, and the admission plugins
+	// and controllers depending on them do not wait for them
+	served := map[string][]string{}
+	for _, gv := range []string{v1alpha1.SchemeGroupVersion.String(), v1beta1.SchemeGroupVersion.String()} {
+		resources, err := server.ClientSet.Discovery().ServerResourcesForGroupVersion(gv)
+		require.NoError(t, err, "%s must be served", gv)
+		for _, resource := range resources.APIResources {
+			served[gv] = append(served[gv], resource.Name)
+		}
+	}
+	assert.ElementsMatch(t, []string{"flunders", "fischers"}, served[v1alpha1.SchemeGroupVersion.String()])
+	assert.ElementsMatch(t, []string{"flunders"}, served[v1beta1.SchemeGroupVersion.String()])
+
+	// the ownership policy, the near miss distance and the flunder defaults
+	// are disabled in 1.1 and dropped on create
+	referenceType := v1alpha1.FlunderReferenceType
+	flunder, err := server.ClientSet.WardleV1alpha1().Flunders("emulated").Create(ctx, &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "emulated"},
+		Spec: v1alpha1.FlunderSpec{
+			Reference:       "missing",
+			ReferenceType:   &referenceType,
+			OwnershipPolicy: v1alpha1.OwnedByReferenceOwnershipPolicy,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, flunder.Spec.OwnershipPolicy)
+	assert.Empty(t, flunder.OwnerReferences)
+
+	distance := int32(1)
+	fischer, err := server.ClientSet.WardleV1alpha1().Fischers().Create(ctx, &v1alpha1.Fischer{
+		ObjectMeta:         metav1.ObjectMeta{Name: "emulated"},
+		DisallowedFlunders: []string{"emulated-banned"},
+		FlunderDefaults:    []v1alpha1.FlunderDefaults{{NamePattern: "emulated-*", Labels: map[string]string{"emulated": "true"}}},
+		NearMissDistance:   &distance,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, fischer.NearMissDistance)
+	assert.Empty(t, fischer.FlunderDefaults)
+}
+
+func TestWardleServerOldestEmulatedVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	server := servertesting.StartTestServerOrDie(t, []string{"--emulated-version=wardle=1.0"})
+	defer server.TearDownFn()
+	ctx := context.Background()
+
+	_, err := server.ClientSet.Discovery().ServerResourcesForGroupVersion(v1alpha1.SchemeGroupVersion.String())
+	assert.NoError(t, err, "v1alpha1 must be served")
+
+	// v1beta1 was introduced in 1.1
+	_, err = server.ClientSet.Discovery().ServerResourcesForGroupVersion(v1beta1.SchemeGroupVersion.String())
+	assert.True(t, apierrors.IsNotFound(err), "v1beta1 must not be discovered, got %v", err)
+	groups, err := server.ClientSet.Discovery().ServerGroups()
+	require.NoError(t, err)
+	for _, group := range groups.Groups {
+		for _, gv := range group.Versions {
+			assert.NotEqual(t, v1beta1.SchemeGroupVersion.String(), gv.GroupVersion, "v1beta1 must not be discovered")
+		}
+	}
+	_, err = server.ClientSet.WardleV1beta1().Flunders("emulated").List(ctx, metav1.ListOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+
+	_, err = server.ClientSet.WardleV1alpha1().Flunders("emulated").Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "emulated"}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+}
+
+func testCRUD(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+	flunders := client.WardleV1alpha1().Flunders("crud")
+
+	created, err := flunders.Create(ctx, &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "crud"},
+		Spec:       v1alpha1.FlunderSpec{Reference: "other"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, created.Spec.ReferenceType, "reference type must be defaulted")
+	assert.Equal(t, v1alpha1.FlunderReferenceType, *created.Spec.ReferenceType)
+
+	got, err := flunders.Get(ctx, "crud", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, created.UID, got.UID)
+
+	got.Labels = map[string]string{"updated": "true"}
+	updated, err := flunders.Update(ctx, got, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "true", updated.Labels["updated"])
+
+	list, err := flunders.List(ctx, metav1.ListOptions{LabelSelector: "updated=true"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "crud", list.Items[0].Name)
+
+	require.NoError(t, flunders.Delete(ctx, "crud", metav1.DeleteOptions{}))
+	_, err = flunders.Get(ctx, "crud", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+}
+
+func testWatch(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+	flunders := client.WardleV1alpha1().Flunders("watch")
+
+	w, err := flunders.Watch(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "watched"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	expectEvent(t, w, watch.Added, "watched")
+
+	require.NoError(t, flunders.Delete(ctx, "watched", metav1.DeleteOptions{}))
+	expectEvent(t, w, watch.Deleted, "watched")
+}
+
+func expectEvent(t *testing.T, w watch.Interface, eventType watch.EventType, name string) {
+	t.Helper()
+	select {
+	case event, ok := <-w.ResultChan():
+		require.True(t, ok, "watch closed unexpectedly")
+		require.Equal(t, eventType, event.Type)
+		assert.Equal(t, name, event.Object.(*v1alpha1.Flunder).Name)
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("timed out waiting for %s event of %s", eventType, name)
+	}
+}
+
+func testFlunderDefaults(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+	fischers := client.WardleV1alpha1().Fischers()
+	flunders := client.WardleV1alpha1().Flunders("defaults")
+
+	_, err := fischers.Create(ctx, &v1alpha1.Fischer{
+		ObjectMeta:      metav1.ObjectMeta{Name: "invalid-defaults"},
+		FlunderDefaults: []v1alpha1.FlunderDefaults{{NamePattern: "[", ReferenceType: "Flounder"}},
+	}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid, got %v", err)
+
+	_, err = fischers.Create(ctx, &v1alpha1.Fischer{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults"},
+		FlunderDefaults: []v1alpha1.FlunderDefaults{{
+			NamePattern: "defaulted-*",
+			Labels:      map[string]string{"team": "defaults"},
+			Reference:   "parent",
+		}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fischers.Delete(ctx, "defaults", metav1.DeleteOptions{}))
+	}()
+
+	// the admission plugin sees the fischer once its informer has caught up
+	var flunder *v1alpha1.Flunder
+	err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+		created, err := flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "defaulted-a"}}, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		if len(created.Labels) != 0 {
+			flunder = created
+			return true, nil
+		}
+		return false, flunders.Delete(ctx, created.Name, metav1.DeleteOptions{})
+	})
+	require.NoError(t, err, "flunder was not defaulted")
+	assert.Equal(t, map[string]string{"team": "defaults"}, flunder.Labels)
+	assert.Equal(t, "parent", flunder.Spec.Reference)
+	require.NotNil(t, flunder.Spec.ReferenceType)
+	assert.Equal(t, v1alpha1.FlunderReferenceType, *flunder.Spec.ReferenceType)
+
+	created, err := flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "other"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, created.Labels)
+}
+
+func testBanFlunder(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+
+	_, err := client.WardleV1alpha1().Fischers().Create(ctx, &v1alpha1.Fischer{
+		ObjectMeta:         metav1.ObjectMeta{Name: "ban"},
+		DisallowedFlunders: []string{"banned"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, client.WardleV1alpha1().Fischers().Delete(ctx, "ban", metav1.DeleteOptions{}))
+	}()
+
+	// the admission plugin sees the fischer once its informer has caught up
+	flunder := &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "banned"}}
+	err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := client.WardleV1alpha1().Flunders("ban").Create(ctx, flunder, metav1.CreateOptions{})
+		if err == nil {
+			return false, client.WardleV1alpha1().Flunders("ban").Delete(ctx, flunder.Name, metav1.DeleteOptions{})
+		}
+		if apierrors.IsForbidden(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	require.NoError(t, err, "banned flunder was not rejected")
+
+	// the status tells why the name was refused
+	_, err = client.WardleV1alpha1().Flunders("ban").Create(ctx, flunder, metav1.CreateOptions{})
+	require.True(t, apierrors.IsForbidden(err), "expected Forbidden, got %v", err)
+	assert.Equal(t, []metav1.StatusCause{{
+		Type:    banflunder.CauseTypeFischerBan,
+		Message: `Fischer "ban" disallows "banned"`,
+		Field:   "metadata.name",
+	}}, err.(apierrors.APIStatus).Status().Details.Causes)
+
+	review, err := client.WardleV1alpha1().FlunderBanReviews().Create(ctx, &v1alpha1.FlunderBanReview{
+		Spec: v1alpha1.FlunderBanReviewSpec{Name: "banned", Namespace: "ban"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.True(t, review.Status.Banned)
+	assert.Equal(t, []v1alpha1.FlunderBan{{Fischer: "ban", Entry: "banned"}}, review.Status.Bans)
+
+	_, err = client.WardleV1alpha1().Flunders("ban").Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "allowed"}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+}
+
+// warningRecorder records the warnings about flunder names returned by the
+// server.
+type warningRecorder struct {
+	lock     sync.Mutex
+	warnings []string
+}
+
+func (r *warningRecorder) HandleWarningHeader(code int, agent string, text string) {
+	if strings.Contains(text, " is deprecated") {
+		// the deprecation of v1alpha1
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.warnings = append(r.warnings, text)
+}
+
+func (r *warningRecorder) pop() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	warnings := r.warnings
+	r.warnings = nil
+	return warnings
+}
+
+func testNearMissWarnings(t *testing.T, config *rest.Config) {
+	ctx := context.Background()
+	recorder := &warningRecorder{}
+	config = rest.CopyConfig(config)
+	config.WarningHandler = recorder
+	client, err := clientset.NewForConfig(config)
+	require.NoError(t, err)
+	flunders := client.WardleV1alpha1().Flunders("nearmiss")
+
+	distance := int32(1)
+	_, err = client.WardleV1alpha1().Fischers().Create(ctx, &v1alpha1.Fischer{
+		ObjectMeta:         metav1.ObjectMeta{Name: "nearmiss"},
+		DisallowedFlunders: []string{"nearmiss"},
+		NearMissDistance:   &distance,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, client.WardleV1alpha1().Fischers().Delete(ctx, "nearmiss", metav1.DeleteOptions{}))
+	}()
+
+	// the registry sees the fischer once its informer has caught up
+	err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+		recorder.pop()
+		if _, err := flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "nearmis"}}, metav1.CreateOptions{}); err != nil {
+			return false, err
+		}
+		if len(recorder.pop()) != 0 {
+			return true, nil
+		}
+		return false, flunders.Delete(ctx, "nearmis", metav1.DeleteOptions{})
+	})
+	require.NoError(t, err, "near miss was not reported")
+
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "NearMiss"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`flunder name "NearMiss" differs only in case from "nearmiss", which Fischer "nearmiss" disallows`}, recorder.pop())
+
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "faraway"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, recorder.pop())
+}
+
+func testFischerWhatIf(t *testing.T, config *rest.Config) {
+	ctx := context.Background()
+	recorder := &warningRecorder{}
+	config = rest.CopyConfig(config)
+	config.WarningHandler = recorder
+	client, err := clientset.NewForConfig(config)
+	require.NoError(t, err)
+	flunders := client.WardleV1alpha1().Flunders("whatif")
+	fischers := client.WardleV1alpha1().Fischers()
+
+	referenceType := v1alpha1.FlunderReferenceType
+	for _, flunder := range []*v1alpha1.Flunder{
+		{ObjectMeta: metav1.ObjectMeta{Name: "target"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "referrer"}, Spec: v1alpha1.FlunderSpec{Reference: "target", ReferenceType: &referenceType}},
+	} {
+		_, err := flunders.Create(ctx, flunder, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	defer func() {
+		assert.NoError(t, flunders.DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{}))
+	}()
+	recorder.pop()
+
+	expected := []string{
+		`Fischer "whatif" would ban the existing flunder whatif/target`,
+		`flunder whatif/referrer references flunder whatif/target, which Fischer "whatif" would ban`,
+	}
+	fischer := &v1alpha1.Fischer{ObjectMeta: metav1.ObjectMeta{Name: "whatif"}, DisallowedFlunders: []string{"target"}}
+
+	// the registry sees the flunders once its informer has caught up
+	err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+		if _, err := fischers.Create(ctx, fischer, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}); err != nil {
+			return false, err
+		}
+		return len(recorder.pop()) != 0, nil
+	})
+	require.NoError(t, err, "dry-run create did not warn")
+	_, err = fischers.Create(ctx, fischer, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	require.NoError(t, err)
+	assert.Equal(t, expected, recorder.pop())
+	_, err = fischers.Get(ctx, "whatif", metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "dry-run create persisted the fischer: %v", err)
+
+	fischer.DisallowedFlunders = nil
+	created, err := fischers.Create(ctx, fischer, metav1.CreateOptions{})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fischers.Delete(ctx, "whatif", metav1.DeleteOptions{}))
+	}()
+	assert.Empty(t, recorder.pop())
+
+	created.DisallowedFlunders = []string{"target"}
+	_, err = fischers.Update(ctx, created, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+	require.NoError(t, err)
+	assert.Equal(t, expected, recorder.pop())
+
+	created.DisallowedFlunders = []string{"unused"}
+	_, err = fischers.Update(ctx, created, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+	require.NoError(t, err)
+	assert.Empty(t, recorder.pop())
+}
+
+func testOwnership(t *testing.T, config *rest.Config) {
+	ctx := context.Background()
+	client, err := clientset.NewForConfig(config)
+	require.NoError(t, err)
+	flunders := client.WardleV1alpha1().Flunders("ownership")
+	defer func() {
+		assert.NoError(t, flunders.DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{}))
+	}()
+
+	root, err := flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "root"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	referenceType := v1alpha1.FlunderReferenceType
+	child, err := flunders.Create(ctx, &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "child"},
+		Spec: v1alpha1.FlunderSpec{
+			Reference:       "root",
+			ReferenceType:   &referenceType,
+			OwnershipPolicy: v1alpha1.OwnedByReferenceOwnershipPolicy,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	blockOwnerDeletion := true
+	assert.Equal(t, []metav1.OwnerReference{{
+		APIVersion:         "wardle.example.com/v1alpha1",
+		Kind:               "Flunder",
+		Name:               "root",
+		UID:                root.UID,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}}, child.OwnerReferences)
+
+	grandchild, err := client.WardleV1beta1().Flunders("ownership").Create(ctx, &v1beta1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "grandchild"},
+		Spec: v1beta1.FlunderSpec{
+			FlunderReference: "child",
+			ReferenceType:    v1beta1.FlunderReferenceType,
+			OwnershipPolicy:  v1beta1.OwnedByReferenceOwnershipPolicy,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Len(t, grandchild.OwnerReferences, 1)
+	assert.Equal(t, child.UID, grandchild.OwnerReferences[0].UID)
+
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "orphan"},
+		Spec: v1alpha1.FlunderSpec{
+			Reference:       "missing",
+			ReferenceType:   &referenceType,
+			OwnershipPolicy: v1alpha1.OwnedByReferenceOwnershipPolicy,
+		},
+	}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid for a missing owner, got %v", err)
+
+	// owner references supplied by the client neither stand in for a missing
+	// owner nor keep a wrong uid
+	forgedRef := metav1.OwnerReference{APIVersion: "wardle.example.com/v1alpha1", Kind: "Flunder", Name: "missing", UID: "forged"}
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "forged-orphan", OwnerReferences: []metav1.OwnerReference{forgedRef}},
+		Spec: v1alpha1.FlunderSpec{
+			Reference:       "missing",
+			ReferenceType:   &referenceType,
+			OwnershipPolicy: v1alpha1.OwnedByReferenceOwnershipPolicy,
+		},
+	}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid for a missing owner with a supplied owner reference, got %v", err)
+
+	forgedRef.Name = "root"
+	sibling, err := flunders.Create(ctx, &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "sibling", OwnerReferences: []metav1.OwnerReference{forgedRef}},
+		Spec: v1alpha1.FlunderSpec{
+			Reference:       "root",
+			ReferenceType:   &referenceType,
+			OwnershipPolicy: v1alpha1.OwnedByReferenceOwnershipPolicy,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Len(t, sibling.OwnerReferences, 1)
+	assert.Equal(t, root.UID, sibling.OwnerReferences[0].UID)
+	require.NoError(t, flunders.Delete(ctx, "sibling", metav1.DeleteOptions{}))
+
+	child.Spec.OwnershipPolicy = v1alpha1.NoneOwnershipPolicy
+	_, err = flunders.Update(ctx, child, metav1.UpdateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid for a changed ownership policy, got %v", err)
+
+	// the garbage collector finds flunders through discovery and watches
+	// their metadata
+	resources, err := client.Discovery().ServerPreferredResources()
+	require.NoError(t, err)
+	deletable := discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"delete", "list", "watch"}}, resources)
+	gvrs, err := discovery.GroupVersionResources(deletable)
+	require.NoError(t, err)
+	assert.Contains(t, gvrs, v1beta1.SchemeGroupVersion.WithResource("flunders"))
+	metadataClient, err := metadata.NewForConfig(config)
+	require.NoError(t, err)
+	list, err := metadataClient.Resource(v1alpha1.SchemeGroupVersion.WithResource("flunders")).Namespace("ownership").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 3)
+
+	// foreground deletion keeps the root until the garbage collector removed
+	// its dependents and the foregroundDeletion finalizer
+	foreground := metav1.DeletePropagationForeground
+	require.NoError(t, flunders.Delete(ctx, "root", metav1.DeleteOptions{PropagationPolicy: &foreground}))
+	root, err = flunders.Get(ctx, "root", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotNil(t, root.DeletionTimestamp)
+	assert.Equal(t, []string{metav1.FinalizerDeleteDependents}, root.Finalizers)
+	root.Finalizers = nil
+	_, err = flunders.Update(ctx, root, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// background deletion removes the owner at once
+	background := metav1.DeletePropagationBackground
+	require.NoError(t, flunders.Delete(ctx, "child", metav1.DeleteOptions{PropagationPolicy: &background}))
+	_, err = flunders.Get(ctx, "child", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+}
+
+func testFlunderPolicy(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+
+	_, err := client.WardleV1alpha1().FlunderPolicies("policy").Create(ctx, &v1alpha1.FlunderPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "ban"},
+		Spec:       v1alpha1.FlunderPolicySpec{DisallowedFlunders: []string{"banned", "reallowed"}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.WardleV1alpha1().FlunderPolicies("policy").Create(ctx, &v1alpha1.FlunderPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow"},
+		Spec:       v1alpha1.FlunderPolicySpec{AllowedFlunders: []string{"reallowed"}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, client.WardleV1alpha1().FlunderPolicies("policy").DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{}))
+	}()
+
+	_, err = client.WardleV1alpha1().FlunderPolicies("policy").Create(ctx, &v1alpha1.FlunderPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+		Spec:       v1alpha1.FlunderPolicySpec{DisallowedFlunders: []string{"both"}, AllowedFlunders: []string{"both"}},
+	}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid, got %v", err)
+
+	// the admission plugin sees the policies once its informer has caught up
+	flunder := &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "banned"}}
+	err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := client.WardleV1alpha1().Flunders("policy").Create(ctx, flunder, metav1.CreateOptions{})
+		if err == nil {
+			return false, client.WardleV1alpha1().Flunders("policy").Delete(ctx, flunder.Name, metav1.DeleteOptions{})
+		}
+		if apierrors.IsForbidden(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	require.NoError(t, err, "banned flunder was not rejected")
+
+	_, err = client.WardleV1alpha1().Flunders("policy").Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "reallowed"}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = client.WardleV1alpha1().Flunders("other").Create(ctx, flunder, metav1.CreateOptions{})
+	assert.NoError(t, err, "policies must only ban names in their namespace")
+
+	review, err := client.WardleV1alpha1().FlunderBanReviews().Create(ctx, &v1alpha1.FlunderBanReview{
+		Spec: v1alpha1.FlunderBanReviewSpec{Name: "banned", Namespace: "policy"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.True(t, review.Status.Banned)
+	assert.Equal(t, []v1alpha1.FlunderBan{{FlunderPolicy: "ban", Entry: "banned"}}, review.Status.Bans)
+}
+
+func testFlunderQuota(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+	quotas := client.WardleV1alpha1().FlunderQuotas("quota")
+	flunders := client.WardleV1alpha1().Flunders("quota")
+
+	_, err := quotas.Create(ctx, &v1alpha1.FlunderQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+		Spec:       v1alpha1.FlunderQuotaSpec{Hard: corev1.ResourceList{"count/fischers.wardle.example.com": resource.MustParse("1")}},
+	}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid, got %v", err)
+
+	_, err = quotas.Create(ctx, &v1alpha1.FlunderQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+		Spec:       v1alpha1.FlunderQuotaSpec{Hard: corev1.ResourceList{v1alpha1.ResourceFlunders: resource.MustParse("2")}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, quotas.Delete(ctx, "quota", metav1.DeleteOptions{}))
+	}()
+
+	// flunders are admitted once the controller has calculated the usage
+	err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "first"}}, metav1.CreateOptions{})
+		if apierrors.IsForbidden(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	require.NoError(t, err, "flunder was not admitted")
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "second"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "third"}}, metav1.CreateOptions{})
+	require.True(t, apierrors.IsForbidden(err), "expected Forbidden, got %v", err)
+	assert.Contains(t, err.Error(), "exceeded quota: quota")
+	fq, err := quotas.Get(ctx, "quota", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), fq.Status.Used.Name(v1alpha1.ResourceFlunders, resource.DecimalSI).Value())
+
+	// the controller releases the usage of deleted flunders
+	require.NoError(t, flunders.Delete(ctx, "first", metav1.DeleteOptions{}))
+	err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "third"}}, metav1.CreateOptions{})
+		if apierrors.IsForbidden(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	require.NoError(t, err, "usage of the deleted flunder was not released")
+}
+
+func testCELValidation(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+	flunders := client.WardleV1alpha1().Flunders("cel")
+
+	_, err := flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "rogue"}}, metav1.CreateOptions{})
+	require.True(t, apierrors.IsForbidden(err), "expected Forbidden, got %v", err)
+	assert.Contains(t, err.Error(), "flunder names in namespace cel must start with team-")
+
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = client.WardleV1alpha1().Flunders("other").Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "rogue"}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+}
+
+func testVersionConversion(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+
+	_, err := client.WardleV1beta1().Flunders("conversion").Create(ctx, &v1beta1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "fischer-ref"},
+		Spec:       v1beta1.FlunderSpec{ReferenceType: v1beta1.FischerReferenceType, FischerReference: "fischer"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	alpha, err := client.WardleV1alpha1().Flunders("conversion").Get(ctx, "fischer-ref", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "fischer", alpha.Spec.Reference)
+	require.NotNil(t, alpha.Spec.ReferenceType)
+	assert.Equal(t, v1alpha1.FischerReferenceType, *alpha.Spec.ReferenceType)
+
+	_, err = client.WardleV1alpha1().Flunders("conversion").Create(ctx, &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "flunder-ref"},
+		Spec:       v1alpha1.FlunderSpec{Reference: "flunder"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	beta, err := client.WardleV1beta1().Flunders("conversion").Get(ctx, "flunder-ref", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "flunder", beta.Spec.FlunderReference)
+	assert.Empty(t, beta.Spec.FischerReference)
+}
+
+func testGeneration(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+	flunders := client.WardleV1beta1().Flunders("generation")
+
+	flunder, err := flunders.Create(ctx, &v1beta1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "generation", Generation: 5},
+		Spec:       v1beta1.FlunderSpec{ReferenceType: v1beta1.FlunderReferenceType, FlunderReference: "first"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), flunder.Generation)
+
+	flunder.Labels = map[string]string{"metadata": "changed"}
+	flunder, err = flunders.Update(ctx, flunder, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), flunder.Generation, "metadata changes must not bump the generation")
+
+	flunder.Spec.FlunderReference = "second"
+	flunder, err = flunders.Update(ctx, flunder, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), flunder.Generation)
+
+	fischers := client.WardleV1beta1().Fischers()
+	fischer, err := fischers.Create(ctx, &v1beta1.Fischer{
+		ObjectMeta:         metav1.ObjectMeta{Name: "generation"},
+		DisallowedFlunders: []string{"generation-banned"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), fischer.Generation)
+
+	fischer.Annotations = map[string]string{"metadata": "changed"}
+	fischer, err = fischers.Update(ctx, fischer, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), fischer.Generation, "metadata changes must not bump the generation")
+
+	fischer.DisallowedFlunders = append(fischer.DisallowedFlunders, "generation-banned-too")
+	fischer, err = fischers.Update(ctx, fischer, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), fischer.Generation)
+	require.NoError(t, fischers.Delete(ctx, "generation", metav1.DeleteOptions{}))
+}
+
+// roundTripperFunc implements http.RoundTripper with a function.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// testProtobuf checks that the clientset talks protobuf to the server by
+// default, including for watches.
+func testProtobuf(t *testing.T, config *rest.Config) {
+	ctx := context.Background()
+	var lock sync.Mutex
+	var contentTypes []string
+	config = rest.CopyConfig(config)
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := rt.RoundTrip(req)
+			if err == nil {
+				lock.Lock()
+				defer lock.Unlock()
+				contentTypes = append(contentTypes, resp.Header.Get("Content-Type"))
+			}
+			return resp, err
+		})
+	})
+	client, err := clientset.NewForConfig(config)
+	require.NoError(t, err)
+	flunders := client.WardleV1beta1().Flunders("protobuf")
+
+	w, err := flunders.Watch(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	created, err := flunders.Create(ctx, &v1beta1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "protobuf", Labels: map[string]string{"encoding": "protobuf"}},
+		Spec:       v1beta1.FlunderSpec{ReferenceType: v1beta1.FischerReferenceType, FischerReference: "fischer"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "fischer", created.Spec.FischerReference)
+	assert.Equal(t, "protobuf", created.Labels["encoding"])
+
+	select {
+	case event, ok := <-w.ResultChan():
+		require.True(t, ok, "watch closed unexpectedly")
+		require.Equal(t, watch.Added, event.Type)
+		assert.Equal(t, "fischer", event.Object.(*v1beta1.Flunder).Spec.FischerReference)
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting for the protobuf flunder to be added")
+	}
+
+	list, err := flunders.List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, created.UID, list.Items[0].UID)
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, contentTypes, 3)
+	for _, contentType := range contentTypes {
+		assert.True(t, strings.HasPrefix(contentType, runtime.ContentTypeProtobuf), "unexpected content type %q", contentType)
+	}
+}
+
+// testCBOR writes and reads a Flunder as CBOR, which the CBORServing feature
+// enables.
+func testCBOR(t *testing.T, config *rest.Config) {
+	httpClient, err := rest.HTTPClientFor(config)
+	require.NoError(t, err)
+	s := cbor.NewSerializer(scheme.Scheme, scheme.Scheme)
+	url := config.Host + "/apis/wardle.example.com/v1beta1/namespaces/cbor/flunders"
+
+	body, err := runtime.Encode(s, &v1beta1.Flunder{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "Flunder"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cbor"},
+		Spec:       v1beta1.FlunderSpec{ReferenceType: v1beta1.FischerReferenceType, FischerReference: "fischer"},
+	})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", runtime.ContentTypeCBOR)
+	req.Header.Set("Accept", runtime.ContentTypeCBOR)
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, runtime.ContentTypeCBOR, resp.Header.Get("Content-Type"))
+
+	req, err = http.NewRequest(http.MethodGet, url+"/cbor", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", runtime.ContentTypeCBOR)
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, runtime.ContentTypeCBOR, resp.Header.Get("Content-Type"))
+
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	obj, err := runtime.Decode(s, body)
+	require.NoError(t, err)
+	require.IsType(t, &v1beta1.Flunder{}, obj)
+	assert.Equal(t, "fischer", obj.(*v1beta1.Flunder).Spec.FischerReference)
+}
+
+func testConversionWebhook(t *testing.T, client clientset.Interface) {
+	request, err := json.Marshal(conversionwebhook.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: conversionwebhook.ConversionReviewAPIVersion, Kind: "ConversionReview"},
+		Request: &conversionwebhook.ConversionRequest{
+			UID:               "uid",
+			DesiredAPIVersion: v1beta1.SchemeGroupVersion.String(),
+			Objects: []runtime.RawExtension{{
+				Raw: []byte(`{"apiVersion":"wardle.example.com/v1alpha1","kind":"Flunder","metadata":{"name":"foo"},"spec":{"reference":"bar","referenceType":"Fischer"}}`),
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	body, err := client.Discovery().RESTClient().Post().AbsPath(conversionwebhook.Path).
+		SetHeader("Content-Type", "application/json").
+		Body(request).
+		Do(context.Background()).
+		Raw()
+	require.NoError(t, err)
+
+	review := conversionwebhook.ConversionReview{}
+	require.NoError(t, json.Unmarshal(body, &review))
+	require.NotNil(t, review.Response)
+	require.Equal(t, metav1.StatusSuccess, review.Response.Result.Status, review.Response.Result.Message)
+	require.Len(t, review.Response.ConvertedObjects, 1)
+
+	converted := v1beta1.Flunder{}
+	require.NoError(t, json.Unmarshal(review.Response.ConvertedObjects[0].Raw, &converted))
+	assert.Equal(t, "bar", converted.Spec.FischerReference)
+}
+
+// testServerSideApply shares a Fischer between field managers, which each
+// apply their own disallowed Flunders.
+func testServerSideApply(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+	fischers := client.WardleV1beta1().Fischers()
+	defer func() {
+		assert.NoError(t, fischers.Delete(ctx, "shared", metav1.DeleteOptions{}))
+	}()
+
+	_, err := fischers.Apply(ctx, applyv1beta1.Fischer("shared").WithDisallowedFlunders("a1", "a2"), metav1.ApplyOptions{FieldManager: "controller-a"})
+	require.NoError(t, err)
+	fischer, err := fischers.Apply(ctx, applyv1beta1.Fischer("shared").WithDisallowedFlunders("b1"), metav1.ApplyOptions{FieldManager: "controller-b"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a1", "a2", "b1"}, fischer.DisallowedFlunders)
+
+	// a manager only removes the names it applied before
+	fischer, err = fischers.Apply(ctx, applyv1beta1.Fischer("shared").WithDisallowedFlunders("a1"), metav1.ApplyOptions{FieldManager: "controller-a"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a1", "b1"}, fischer.DisallowedFlunders)
+	fischer, err = fischers.Apply(ctx, applyv1beta1.Fischer("shared"), metav1.ApplyOptions{FieldManager: "controller-b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1"}, fischer.DisallowedFlunders)
+
+	// names applied by several managers stay until no manager applies them
+	_, err = fischers.Apply(ctx, applyv1beta1.Fischer("shared").WithDisallowedFlunders("a1"), metav1.ApplyOptions{FieldManager: "controller-b"})
+	require.NoError(t, err)
+	fischer, err = fischers.Apply(ctx, applyv1beta1.Fischer("shared"), metav1.ApplyOptions{FieldManager: "controller-a"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1"}, fischer.DisallowedFlunders)
+
+	// the v1alpha1 list is atomic, so an apply in v1alpha1 replaces the names
+	// of all managers
+	alpha, err := client.WardleV1alpha1().Fischers().Apply(ctx, applyv1alpha1.Fischer("shared").WithDisallowedFlunders("alpha"), metav1.ApplyOptions{FieldManager: "controller-a"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alpha"}, alpha.DisallowedFlunders)
+}
+
+// testManagedFieldsUpgrade shows that names applied in v1alpha1 are owned
+// item by item, so that v1beta1 managers can add their own names.
+func testManagedFieldsUpgrade(t *testing.T, client clientset.Interface) {
+	ctx := context.Background()
+	fischers := client.WardleV1beta1().Fischers()
+	defer func() {
+		assert.NoError(t, fischers.Delete(ctx, "upgraded", metav1.DeleteOptions{}))
+	}()
+
+	_, err := client.WardleV1alpha1().Fischers().Apply(ctx, applyv1alpha1.Fischer("upgraded").WithDisallowedFlunders("legacy"), metav1.ApplyOptions{FieldManager: "legacy"})
+	require.NoError(t, err)
+
+	fischer, err := fischers.Apply(ctx, applyv1beta1.Fischer("upgraded").WithDisallowedFlunders("new"), metav1.ApplyOptions{FieldManager: "controller"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"legacy", "new"}, fischer.DisallowedFlunders)
+	for _, entry := range fischer.ManagedFields {
+		assert.Equal(t, v1beta1.SchemeGroupVersion.String(), entry.APIVersion, "managed fields of %s", entry.Manager)
+	}
+
+	fischer, err = fischers.Apply(ctx, applyv1beta1.Fischer("upgraded").WithDisallowedFlunders("new"), metav1.ApplyOptions{FieldManager: "legacy"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"new"}, fischer.DisallowedFlunders)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apiserver/pkg/server/healthz"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+)
+
+// wardleInformersCheckName is the name of the readyz check, served at /readyz/wardle-informers.
+const wardleInformersCheckName = "wardle-informers"
+
+// informerSyncChecker is a readyz check that passes once all informers of the
+// wardle shared informer factory have been started and have synced.
+type informerSyncChecker struct {
+	factory informers.SharedInformerFactory
+	started atomic.Bool
+}
+
+var _ healthz.HealthChecker = &informerSyncChecker{}
+
+// newInformerSyncChecker returns a readyz check for the informers of the given factory.
+func newInformerSyncChecker(factory informers.SharedInformerFactory) *informerSyncChecker {
+	return &informerSyncChecker{factory: factory}
+}
+
+func (c *informerSyncChecker) Name() string {
+	return wardleInformersCheckName
+}
+
+// Check reports every informer that has not synced yet.
+func (c *informerSyncChecker) Check(_ *http.Request) error {
+	if !c.started.Load() {
+		return fmt.Errorf("wardle informers not started yet")
+	}
+
+	stopCh := make(chan struct{})
+	// Close stopCh to force checking if informers are synced now.
+	close(stopCh)
+
+	if notSynced := notSyncedInformers(c.factory.WaitForCacheSync(stopCh)); len(notSynced) > 0 {
+		return fmt.Errorf("%d wardle informers not synced yet: %s", len(notSynced), strings.Join(notSynced, ", "))
+	}
+	return nil
+}
+
+// start starts the informers of the factory and marks the check as started.
+func (c *informerSyncChecker) start(stopCh <-chan struct{}) {
+	c.factory.Start(stopCh)
+	c.started.Store(true)
+}
+
+// waitForSync blocks until all started informers have synced. It returns an
+// error naming the informers that did not sync if timeout is exceeded first.
+// It returns nil if ctx is cancelled first, because the server is shutting
+// down then.
+func (c *informerSyncChecker) waitForSync(ctx context.Context, timeout time.Duration) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	notSynced := notSyncedInformers(c.factory.WaitForCacheSync(timeoutCtx.Done()))
+	if ctx.Err() != nil {
+		return nil
+	}
+	if len(notSynced) > 0 {
+		return fmt.Errorf("wardle informers not synced within %v: %s", timeout, strings.Join(notSynced, ", "))
+	}
+	return nil
+}
+
+// notSyncedInformers returns the sorted type names of the informers which have not synced.
+func notSyncedInformers(synced map[reflect.Type]bool) []string {
+	notSynced := []string{}
+	for informerType, ok := range synced {
+		if !ok {
+			notSynced = append(notSynced, informerType.String())
+		}
+	}
+	sort.Strings(notSynced)
+	return notSynced
+}
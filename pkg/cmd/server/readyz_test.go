@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/fake"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+)
+
+func TestInformerSyncChecker(t *testing.T) {
+	t.Run("not started", func(t *testing.T) {
+		factory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+		factory.Wardle().V1alpha1().Fischers().Informer()
+
+		checker := newInformerSyncChecker(factory)
+		assert.Equal(t, "wardle-informers", checker.Name())
+		assert.ErrorContains(t, checker.Check(nil), "not started")
+	})
+
+	t.Run("synced", func(t *testing.T) {
+		factory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+		factory.Wardle().V1alpha1().Fischers().Informer()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		checker := newInformerSyncChecker(factory)
+		checker.start(ctx.Done())
+		require.NoError(t, checker.waitForSync(ctx, wait.ForeverTestTimeout))
+		assert.NoError(t, checker.Check(nil))
+	})
+
+	t.Run("not synced", func(t *testing.T) {
+		cs := fake.NewSimpleClientset()
+		cs.PrependReactor("list", "fischers", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("fischers are not available")
+		})
+		factory := informers.NewSharedInformerFactory(cs, 0)
+		factory.Wardle().V1alpha1().Fischers().Informer()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		checker := newInformerSyncChecker(factory)
+		checker.start(ctx.Done())
+		assert.ErrorContains(t, checker.Check(nil), "*v1alpha1.Fischer")
+		assert.ErrorContains(t, checker.waitForSync(ctx, 100*time.Millisecond), "not synced within 100ms")
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		cs := fake.NewSimpleClientset()
+		cs.PrependReactor("list", "fischers", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("fischers are not available")
+		})
+		factory := informers.NewSharedInformerFactory(cs, 0)
+		factory.Wardle().V1alpha1().Fischers().Informer()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		checker := newInformerSyncChecker(factory)
+		checker.start(ctx.Done())
+		cancel()
+		assert.NoError(t, checker.waitForSync(ctx, wait.ForeverTestTimeout), "cancelling the server must not fail the wait")
+	})
+}
@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"slices"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -38,9 +40,14 @@ import (
 	"k8s.io/component-base/featuregate"
 	baseversion "k8s.io/component-base/version"
 	"k8s.io/sample-apiserver/pkg/admission/plugin/banflunder"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/celvalidation"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/flunderdefaults"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/flunderquota"
 	"k8s.io/sample-apiserver/pkg/admission/wardleinitializer"
 	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
 	"k8s.io/sample-apiserver/pkg/apiserver"
+	"k8s.io/sample-apiserver/pkg/conversionwebhook"
+	"k8s.io/sample-apiserver/pkg/features"
 	clientset "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
 	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
 	sampleopenapi "k8s.io/sample-apiserver/pkg/generated/openapi"
@@ -58,6 +65,14 @@ type WardleServerOptions struct {
 	StdErr                io.Writer
 
 	AlternateDNS []string
+
+	// InformerSyncTimeout is how long the server waits for the wardle informers
+	// to sync after startup before it gives up and exits. Zero means no limit.
+	InformerSyncTimeout time.Duration
+
+	// EnableConversionWebhook serves the version conversions as a
+	// CustomResourceDefinition conversion webhook.
+	EnableConversionWebhook bool
 }
 
 func WardleVersionToKubeVersion(ver *version.Version) *version.Version {
@@ -74,6 +89,56 @@ func WardleVersionToKubeVersion(ver *version.Version) *version.Version {
 	return mappedVer
 }
 
+// wardleVersionToKubeEmulationVersion maps the wardle emulation version to the
+// emulation version of the kube component. Like for every kube component, its
+// versioned features only support emulating the previous minor version, so
+// the wardle versions older than that emulate it too.
+func wardleVersionToKubeEmulationVersion(ver *version.Version) *version.Version {
+	kubeVer := WardleVersionToKubeVersion(ver)
+	if kubeVer == nil {
+		return nil
+	}
+	minKubeVer := utilversion.DefaultKubeEffectiveVersion().BinaryVersion().SubtractMinor(1)
+	if kubeVer.LessThan(minKubeVer) {
+		return minKubeVer
+	}
+	return kubeVer
+}
+
+// minWardleEmulationVersion is the oldest wardle version the server can
+// emulate, the first one.
+var minWardleEmulationVersion = version.MajorMinor(1, 0)
+
+// effectiveVersion is an effective version which can emulate every version
+// down to minEmulationVersion, rather than only the previous minor version of
+// the binary.
+type effectiveVersion struct {
+	utilversion.MutableEffectiveVersion
+	minEmulationVersion *version.Version
+}
+
+func newEffectiveVersion(binaryVersion string, minEmulationVersion *version.Version) *effectiveVersion {
+	return &effectiveVersion{
+		MutableEffectiveVersion: utilversion.NewEffectiveVersion(binaryVersion),
+		minEmulationVersion:     minEmulationVersion,
+	}
+}
+
+func (v *effectiveVersion) Validate() []error {
+	var errs []error
+	binaryVersion := v.BinaryVersion().WithPatch(0)
+	emulationVersion := v.EmulationVersion()
+	if emulationVersion.GreaterThan(binaryVersion) || emulationVersion.LessThan(v.minEmulationVersion) {
+		errs = append(errs, fmt.Errorf("emulation version %s is not between [%s, %s]", emulationVersion.String(), v.minEmulationVersion.String(), binaryVersion.String()))
+	}
+	// minCompatibilityVersion can only be 1.{binaryMinor-1}, like for every
+	// other component.
+	if compatVersion := binaryVersion.SubtractMinor(1); !v.MinCompatibilityVersion().EqualTo(compatVersion) {
+		errs = append(errs, fmt.Errorf("minCompatibilityVersion version %s is not %s", v.MinCompatibilityVersion().String(), compatVersion.String()))
+	}
+	return errs
+}
+
 // NewWardleServerOptions returns a new WardleServerOptions
 func NewWardleServerOptions(out, errOut io.Writer) *WardleServerOptions {
 	o := &WardleServerOptions{
@@ -116,6 +181,12 @@ func NewCommandStartWardleServer(ctx context.Context, defaults *WardleServerOpti
 
 	flags := cmd.Flags()
 	o.RecommendedOptions.AddFlags(flags)
+	flags.DurationVar(&o.InformerSyncTimeout, "informer-sync-timeout", o.InformerSyncTimeout, ""+
+		"The maximum duration to wait for the wardle informers to sync after startup. "+
+		"The server exits if they have not synced in time. Zero means no limit.")
+	flags.BoolVar(&o.EnableConversionWebhook, "enable-conversion-webhook", o.EnableConversionWebhook, ""+
+		"Serve the conversions between the wardle API versions as a CustomResourceDefinition "+
+		"conversion webhook at "+conversionwebhook.Path+".")
 
 	// The following lines demonstrate how to configure version compatibility and feature gates
 	// for the "Wardle" component, as an example of KEP-4330.
@@ -134,10 +205,10 @@ func NewCommandStartWardleServer(ctx context.Context, defaults *WardleServerOpti
 	// associating it with its effective version and feature gate configuration.
 	// Will skip if the component has been registered, like in the integration test.
 	_, wardleFeatureGate := utilversion.DefaultComponentGlobalsRegistry.ComponentGlobalsOrRegister(
-		apiserver.WardleComponentName, utilversion.NewEffectiveVersion(defaultWardleVersion),
+		apiserver.WardleComponentName, newEffectiveVersion(defaultWardleVersion, minWardleEmulationVersion),
 		featuregate.NewVersionedFeatureGate(version.MustParse(defaultWardleVersion)))
 
-	// Add versioned feature specifications for the "BanFlunder" feature.
+	// Add versioned feature specifications for the "BanFlunder" and "CBORServing" features.
 	// These specifications, together with the effective version, determine if the feature is enabled.
 	utilruntime.Must(wardleFeatureGate.AddVersioned(map[featuregate.Feature]featuregate.VersionedSpecs{
 		"BanFlunder": {
@@ -145,15 +216,22 @@ func NewCommandStartWardleServer(ctx context.Context, defaults *WardleServerOpti
 			{Version: version.MustParse("1.1"), Default: true, PreRelease: featuregate.Beta},
 			{Version: version.MustParse("1.0"), Default: false, PreRelease: featuregate.Alpha},
 		},
+		apiserver.CBORServingFeature: {
+			{Version: version.MustParse("1.2"), Default: false, PreRelease: featuregate.Alpha},
+		},
 	}))
 
+	// Add the features gating fields of the wardle types, which the registry
+	// strategies drop on write while they are disabled.
+	utilruntime.Must(wardleFeatureGate.AddVersioned(features.DefaultVersionedFeatureGates))
+
 	// Register the default kube component if not already present in the global registry.
 	_, _ = utilversion.DefaultComponentGlobalsRegistry.ComponentGlobalsOrRegister(utilversion.DefaultKubeComponent,
 		utilversion.NewEffectiveVersion(baseversion.DefaultKubeBinaryVersion), utilfeature.DefaultMutableFeatureGate)
 
 	// Set the emulation version mapping from the "Wardle" component to the kube component.
 	// This ensures that the emulation version of the latter is determined by the emulation version of the former.
-	utilruntime.Must(utilversion.DefaultComponentGlobalsRegistry.SetEmulationVersionMapping(apiserver.WardleComponentName, utilversion.DefaultKubeComponent, WardleVersionToKubeVersion))
+	utilruntime.Must(utilversion.DefaultComponentGlobalsRegistry.SetEmulationVersionMapping(apiserver.WardleComponentName, utilversion.DefaultKubeComponent, wardleVersionToKubeEmulationVersion))
 
 	utilversion.DefaultComponentGlobalsRegistry.AddFlags(flags)
 
@@ -170,6 +248,12 @@ func (o WardleServerOptions) Validate(args []string) error {
 
 // Complete fills in fields required to have valid data
 func (o *WardleServerOptions) Complete() error {
+	apiserver.Codecs.SetCBOREnabled(utilversion.DefaultComponentGlobalsRegistry.FeatureGateFor(apiserver.WardleComponentName).Enabled(apiserver.CBORServingFeature))
+
+	// defaults are applied before BanFlunder sees the labels of new flunders
+	flunderdefaults.Register(o.RecommendedOptions.Admission.Plugins)
+	o.RecommendedOptions.Admission.RecommendedPluginOrder = append(o.RecommendedOptions.Admission.RecommendedPluginOrder, flunderdefaults.PluginName)
+
 	if utilversion.DefaultComponentGlobalsRegistry.FeatureGateFor(apiserver.WardleComponentName).Enabled("BanFlunder") {
 		// register admission plugins
 		banflunder.Register(o.RecommendedOptions.Admission.Plugins)
@@ -177,6 +261,14 @@ func (o *WardleServerOptions) Complete() error {
 		// add admission plugins to the RecommendedPluginOrder
 		o.RecommendedOptions.Admission.RecommendedPluginOrder = append(o.RecommendedOptions.Admission.RecommendedPluginOrder, "BanFlunder")
 	}
+
+	// CEL validation rules are read from the admission configuration file
+	celvalidation.Register(o.RecommendedOptions.Admission.Plugins)
+	o.RecommendedOptions.Admission.RecommendedPluginOrder = append(o.RecommendedOptions.Admission.RecommendedPluginOrder, celvalidation.PluginName)
+
+	// quota is charged last, after all other admission plugins admitted the object
+	flunderquota.Register(o.RecommendedOptions.Admission.Plugins)
+	o.RecommendedOptions.Admission.RecommendedPluginOrder = append(o.RecommendedOptions.Admission.RecommendedPluginOrder, flunderquota.PluginName)
 	return nil
 }
 
@@ -194,10 +286,15 @@ func (o *WardleServerOptions) Config() (*apiserver.Config, error) {
 		}
 		informerFactory := informers.NewSharedInformerFactory(client, c.LoopbackClientConfig.Timeout)
 		o.SharedInformerFactory = informerFactory
-		return []admission.PluginInitializer{wardleinitializer.New(informerFactory)}, nil
+		servedKinds, err := apiserver.ServedKinds(c.EffectiveVersion.EmulationVersion())
+		if err != nil {
+			return nil, err
+		}
+		return []admission.PluginInitializer{wardleinitializer.New(informerFactory, client).WithServedKinds(servedKinds)}, nil
 	}
 
-	serverConfig := genericapiserver.NewRecommendedConfig(apiserver.Codecs)
+	// the generic endpoints refuse to serve CBOR, only the wardle API group does
+	serverConfig := genericapiserver.NewRecommendedConfig(apiserver.Codecs.CodecFactory)
 
 	serverConfig.OpenAPIConfig = genericapiserver.DefaultOpenAPIConfig(sampleopenapi.GetOpenAPIDefinitions, openapi.NewDefinitionNamer(apiserver.Scheme))
 	serverConfig.OpenAPIConfig.Info.Title = "Wardle"
@@ -216,11 +313,28 @@ func (o *WardleServerOptions) Config() (*apiserver.Config, error) {
 
 	config := &apiserver.Config{
 		GenericConfig: serverConfig,
-		ExtraConfig:   apiserver.ExtraConfig{},
+		ExtraConfig: apiserver.ExtraConfig{
+			SharedInformerFactory:   o.SharedInformerFactory,
+			EnableConversionWebhook: o.EnableConversionWebhook,
+			FeatureGate:             utilversion.DefaultComponentGlobalsRegistry.FeatureGateFor(apiserver.WardleComponentName),
+			BanFlunderEnabled:       admissionPluginEnabled(o.RecommendedOptions.Admission, banflunder.PluginName),
+		},
 	}
 	return config, nil
 }
 
+// admissionPluginEnabled returns whether the admission options run the named
+// plugin, which they do if it is in the recommended order and not turned off.
+func admissionPluginEnabled(o *genericoptions.AdmissionOptions, name string) bool {
+	if o == nil || !slices.Contains(o.RecommendedPluginOrder, name) {
+		return false
+	}
+	if slices.Contains(o.EnablePlugins, name) {
+		return true
+	}
+	return !o.DefaultOffPlugins.Has(name) && !slices.Contains(o.DisablePlugins, name)
+}
+
 // RunWardleServer starts a new WardleServer given WardleServerOptions
 func (o WardleServerOptions) RunWardleServer(ctx context.Context) error {
 	config, err := o.Config()
@@ -233,9 +347,17 @@ func (o WardleServerOptions) RunWardleServer(ctx context.Context) error {
 		return err
 	}
 
+	informersChecker := newInformerSyncChecker(o.SharedInformerFactory)
+	if err := server.GenericAPIServer.AddReadyzChecks(informersChecker); err != nil {
+		return err
+	}
+
 	server.GenericAPIServer.AddPostStartHookOrDie("start-sample-server-informers", func(context genericapiserver.PostStartHookContext) error {
 		config.GenericConfig.SharedInformerFactory.Start(context.Done())
-		o.SharedInformerFactory.Start(context.Done())
+		informersChecker.start(context.Done())
+		if o.InformerSyncTimeout > 0 {
+			return informersChecker.waitForSync(context, o.InformerSyncTimeout)
+		}
 		return nil
 	})
 
@@ -19,7 +19,9 @@ package server
 import (
 	"testing"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/version"
+	genericoptions "k8s.io/apiserver/pkg/server/options"
 	utilversion "k8s.io/apiserver/pkg/util/version"
 
 	"github.com/stretchr/testify/assert"
@@ -66,3 +68,90 @@ func TestWardleEmulationVersionToKubeEmulationVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestEffectiveVersionValidate(t *testing.T) {
+	testCases := []struct {
+		emulationVersion string
+		expectErr        bool
+	}{
+		{emulationVersion: "1.0"},
+		{emulationVersion: "1.1"},
+		{emulationVersion: "1.2"},
+		{emulationVersion: "1.3", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.emulationVersion, func(t *testing.T) {
+			effectiveVersion := newEffectiveVersion("1.2", minWardleEmulationVersion)
+			effectiveVersion.SetEmulationVersion(version.MustParse(tc.emulationVersion))
+			if tc.expectErr {
+				assert.NotEmpty(t, effectiveVersion.Validate())
+			} else {
+				assert.Empty(t, effectiveVersion.Validate())
+			}
+		})
+	}
+}
+
+func TestAdmissionPluginEnabled(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		modify   func(*genericoptions.AdmissionOptions)
+		expected bool
+	}{
+		{
+			desc:     "recommended",
+			modify:   func(*genericoptions.AdmissionOptions) {},
+			expected: true,
+		},
+		{
+			desc:   "not registered",
+			modify: func(o *genericoptions.AdmissionOptions) { o.RecommendedPluginOrder = nil },
+		},
+		{
+			desc:   "disabled",
+			modify: func(o *genericoptions.AdmissionOptions) { o.DisablePlugins = []string{"BanFlunder"} },
+		},
+		{
+			desc:   "off by default",
+			modify: func(o *genericoptions.AdmissionOptions) { o.DefaultOffPlugins = sets.New("BanFlunder") },
+		},
+		{
+			desc: "enabled",
+			modify: func(o *genericoptions.AdmissionOptions) {
+				o.DefaultOffPlugins = sets.New("BanFlunder")
+				o.EnablePlugins = []string{"BanFlunder"}
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			o := genericoptions.NewAdmissionOptions()
+			o.RecommendedPluginOrder = append(o.RecommendedPluginOrder, "BanFlunder")
+			tc.modify(o)
+			assert.Equal(t, tc.expected, admissionPluginEnabled(o, "BanFlunder"))
+		})
+	}
+}
+
+func TestWardleVersionToKubeEmulationVersion(t *testing.T) {
+	kubeBinaryVersion := utilversion.DefaultKubeEffectiveVersion().BinaryVersion()
+
+	testCases := []struct {
+		wardleEmulationVer       *version.Version
+		expectedKubeEmulationVer *version.Version
+	}{
+		{wardleEmulationVer: version.MajorMinor(1, 2), expectedKubeEmulationVer: kubeBinaryVersion},
+		{wardleEmulationVer: version.MajorMinor(1, 1), expectedKubeEmulationVer: kubeBinaryVersion.SubtractMinor(1)},
+		{wardleEmulationVer: version.MajorMinor(1, 0), expectedKubeEmulationVer: kubeBinaryVersion.SubtractMinor(1)},
+		{wardleEmulationVer: version.MajorMinor(2, 10)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wardleEmulationVer.String(), func(t *testing.T) {
+			assert.True(t, wardleVersionToKubeEmulationVersion(tc.wardleEmulationVer).EqualTo(tc.expectedKubeEmulationVer))
+		})
+	}
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testing starts a wardle server in-process, backed by an embedded
+// etcd, for integration tests.
+package testing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/storage/etcd3/testserver"
+	utilversion "k8s.io/apiserver/pkg/util/version"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/sample-apiserver/pkg/cmd/server"
+	clientset "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
+)
+
+// disabledAdmissionPlugins need the core API of a kube-apiserver, which the
+// test server does not have.
+var disabledAdmissionPlugins = []string{
+	"NamespaceLifecycle",
+	"MutatingAdmissionWebhook",
+	"ValidatingAdmissionPolicy",
+	"ValidatingAdmissionWebhook",
+}
+
+// TearDownFunc is to be called to tear down a test server.
+type TearDownFunc func()
+
+// TestServer holds the state of a wardle server started by StartTestServer.
+type TestServer struct {
+	// ClientConfig authenticates as a member of system:masters.
+	ClientConfig *restclient.Config
+	// ClientSet uses ClientConfig.
+	ClientSet clientset.Interface
+	// TearDownFn stops the server and removes TmpDir.
+	TearDownFn TearDownFunc
+	// TmpDir holds the certificates and kubeconfig of the server.
+	TmpDir string
+}
+
+// StartTestServer starts an embedded etcd and a wardle server on a free port of
+// 127.0.0.1, and waits until the server is ready. customFlags are passed to the
+// server after the flags of the test server, so they may override them.
+//
+// There is no kube-apiserver. Requests are authenticated with a client
+// certificate of the system:masters group, which is always authorized, and the
+// admission plugins which need the core API are disabled. The kubeconfig which
+// the server requires for the core API points to the server itself and is not
+// used.
+//
+// The wardle server registers itself in the global component registry, which
+// is reset on startup and teardown. Test servers must therefore not run in
+// parallel.
+func StartTestServer(t testing.TB, customFlags []string) (result TestServer, err error) {
+	tearDowns := []func(){}
+	defer func() {
+		if err != nil {
+			for i := len(tearDowns) - 1; i >= 0; i-- {
+				tearDowns[i]()
+			}
+		}
+	}()
+
+	result.TmpDir, err = os.MkdirTemp("", "wardle-server")
+	if err != nil {
+		return result, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	tearDowns = append(tearDowns, func() { os.RemoveAll(result.TmpDir) })
+
+	etcdConfig := testserver.NewTestConfig(t)
+	etcdClient := testserver.RunEtcd(t, etcdConfig)
+	tearDowns = append(tearDowns, func() { etcdClient.Close() })
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return result, fmt.Errorf("failed to listen on a free port: %w", err)
+	}
+	tearDowns = append(tearDowns, func() { listener.Close() })
+	host := listener.Addr().String()
+
+	clientCert, clientKey, err := writeClientCA(result.TmpDir)
+	if err != nil {
+		return result, err
+	}
+	kubeconfig := filepath.Join(result.TmpDir, "kubeconfig")
+	if err := clientcmd.WriteToFile(clientcmdapi.Config{
+		Clusters:       map[string]*clientcmdapi.Cluster{"wardle": {Server: "https://" + host, InsecureSkipTLSVerify: true}},
+		Contexts:       map[string]*clientcmdapi.Context{"wardle": {Cluster: "wardle"}},
+		CurrentContext: "wardle",
+	}, kubeconfig); err != nil {
+		return result, fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+
+	utilversion.DefaultComponentGlobalsRegistry.Reset()
+	tearDowns = append(tearDowns, utilversion.DefaultComponentGlobalsRegistry.Reset)
+
+	certDir := filepath.Join(result.TmpDir, "certs")
+	options := server.NewWardleServerOptions(os.Stdout, os.Stderr)
+	// the command copies the options, but shares RecommendedOptions
+	options.RecommendedOptions.SecureServing.Listener = listener
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := server.NewCommandStartWardleServer(ctx, options)
+	cmd.SilenceUsage = true
+	cmd.SetArgs(append([]string{
+		"--etcd-servers=" + etcdConfig.ListenClientUrls[0].String(),
+		"--cert-dir=" + certDir,
+		"--client-ca-file=" + filepath.Join(result.TmpDir, "client-ca.crt"),
+		"--kubeconfig=" + kubeconfig,
+		"--authentication-kubeconfig=" + kubeconfig,
+		"--authorization-kubeconfig=" + kubeconfig,
+		"--authentication-skip-lookup",
+		"--disable-admission-plugins=" + strings.Join(disabledAdmissionPlugins, ","),
+		"--enable-priority-and-fairness=false",
+	}, customFlags...))
+
+	// runErr is set before stopped is closed
+	var runErr error
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		runErr = cmd.Execute()
+	}()
+	tearDowns = append(tearDowns, func() {
+		cancel()
+		select {
+		case <-stopped:
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Errorf("wardle server did not stop within %v", wait.ForeverTestTimeout)
+		}
+	})
+
+	result.ClientConfig = &restclient.Config{
+		Host: "https://" + host,
+		TLSClientConfig: restclient.TLSClientConfig{
+			CAFile:   filepath.Join(certDir, "apiserver.crt"),
+			CertData: clientCert,
+			KeyData:  clientKey,
+		},
+	}
+	if err := waitForReady(ctx, stopped, certDir, result.ClientConfig); err != nil {
+		select {
+		case <-stopped:
+			return result, fmt.Errorf("wardle server exited: %w", runErr)
+		default:
+			return result, err
+		}
+	}
+	result.ClientSet, err = clientset.NewForConfig(result.ClientConfig)
+	if err != nil {
+		return result, fmt.Errorf("failed to create a clientset: %w", err)
+	}
+
+	result.TearDownFn = func() {
+		for i := len(tearDowns) - 1; i >= 0; i-- {
+			tearDowns[i]()
+		}
+	}
+	return result, nil
+}
+
+// StartTestServerOrDie calls StartTestServer and fails the test if it returns an error.
+func StartTestServerOrDie(t testing.TB, flags []string) *TestServer {
+	result, err := StartTestServer(t, flags)
+	if err != nil {
+		t.Fatalf("failed to launch wardle server: %v", err)
+	}
+	return &result
+}
+
+// waitForReady waits until /readyz of the server succeeds, or the server
+// exits.
+func waitForReady(ctx context.Context, stopped <-chan struct{}, certDir string, config *restclient.Config) error {
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, time.Minute, true, func(ctx context.Context) (bool, error) {
+		select {
+		case <-stopped:
+			return false, fmt.Errorf("wardle server exited")
+		default:
+		}
+
+		// the serving certificate is generated on startup
+		if _, err := os.Stat(filepath.Join(certDir, "apiserver.crt")); err != nil {
+			lastErr = err
+			return false, nil
+		}
+		client, err := clientset.NewForConfig(config)
+		if err != nil {
+			return false, err
+		}
+		status := 0
+		result := client.Discovery().RESTClient().Get().AbsPath("/readyz").Do(ctx).StatusCode(&status)
+		if status != http.StatusOK {
+			lastErr = fmt.Errorf("/readyz returned %d: %w", status, result.Error())
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wardle server did not become ready: %w, last error: %v", err, lastErr)
+	}
+	return nil
+}
+
+// writeClientCA writes a client CA to dir and returns a client certificate and
+// key signed by it for a member of system:masters.
+func writeClientCA(dir string) (cert, key []byte, err error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	caCert, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: "wardle-client-ca"}, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := certutil.WriteCert(filepath.Join(dir, "client-ca.crt"), encodeCertPEM(caCert)); err != nil {
+		return nil, nil, err
+	}
+
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientCert, err := signClientCert(caCert, caKey, clientKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(clientKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encodeCertPEM(clientCert), keyPEM, nil
+}
+
+func signClientCert(caCert *x509.Certificate, caKey crypto.Signer, key *ecdsa.PrivateKey) (*x509.Certificate, error) {
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject: pkix.Name{
+			CommonName:   "wardle-test",
+			Organization: []string{"system:masters"},
+		},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(24 * time.Hour),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+func encodeCertPEM(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateBlockType, Bytes: cert.Raw})
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wardlectl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+	"k8s.io/sample-apiserver/pkg/clientexpansion"
+)
+
+func newCommandBan(ctx context.Context, o *WardlectlOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ban FISCHER NAME...",
+		Short: "Add Flunder names to the disallowed Flunders of a Fischer, creating it if needed",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.updateDisallowedFlunders(ctx, args[0], true, func(names []string) []string {
+				for _, name := range args[1:] {
+					if !slices.Contains(names, name) {
+						names = append(names, name)
+					}
+				}
+				return names
+			})
+		},
+	}
+}
+
+func newCommandUnban(ctx context.Context, o *WardlectlOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unban FISCHER NAME...",
+		Short: "Remove Flunder names from the disallowed Flunders of a Fischer",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.updateDisallowedFlunders(ctx, args[0], false, func(names []string) []string {
+				return slices.DeleteFunc(names, func(name string) bool {
+					return slices.Contains(args[1:], name)
+				})
+			})
+		},
+	}
+}
+
+// updateDisallowedFlunders updates the disallowed Flunders of the named Fischer
+// in the API version of the options, and retries on conflicts. The Fischer is
+// created if it does not exist and create is true.
+func (o *WardlectlOptions) updateDisallowedFlunders(ctx context.Context, name string, create bool, update func([]string) []string) error {
+	var action string
+	var err error
+	switch o.APIVersion {
+	case v1beta1.SchemeGroupVersion.Version:
+		var newFischer func() *v1beta1.Fischer
+		if create {
+			newFischer = func() *v1beta1.Fischer { return &v1beta1.Fischer{} }
+		}
+		action, err = updateOrCreate(ctx, o.ClientSet.WardleV1beta1().Fischers(), name, newFischer,
+			func(fischer *v1beta1.Fischer) { fischer.DisallowedFlunders = update(fischer.DisallowedFlunders) })
+	default:
+		var newFischer func() *v1alpha1.Fischer
+		if create {
+			newFischer = func() *v1alpha1.Fischer { return &v1alpha1.Fischer{} }
+		}
+		action, err = updateOrCreate(ctx, o.ClientSet.WardleV1alpha1().Fischers(), name, newFischer,
+			func(fischer *v1alpha1.Fischer) { fischer.DisallowedFlunders = update(fischer.DisallowedFlunders) })
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.StdOut, "fischer/%s %s\n", name, action)
+	return err
+}
+
+// fischerClient gets, updates and creates Fischers of one API version.
+type fischerClient[T clientexpansion.Object] interface {
+	clientexpansion.Updater[T]
+	Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
+}
+
+// updateOrCreate lets mutate change the named object and updates it, retrying
+// on conflicts. If the object does not exist and newObj is not nil, mutate
+// changes the object returned by newObj instead, which is created. If another
+// client creates the object first, it is updated. It returns whether the
+// object was "created" or "updated".
+func updateOrCreate[T clientexpansion.Object](ctx context.Context, c fischerClient[T], name string, newObj func() T, mutate func(T)) (string, error) {
+	var action string
+	err := retry.OnError(retry.DefaultRetry, apierrors.IsAlreadyExists, func() error {
+		action = "updated"
+		_, err := clientexpansion.Update(ctx, c, name, func(obj T) bool {
+			mutate(obj)
+			return true
+		})
+		if !apierrors.IsNotFound(err) || newObj == nil {
+			return err
+		}
+
+		action = "created"
+		obj := newObj()
+		obj.SetName(name)
+		mutate(obj)
+		_, err = c.Create(ctx, obj, metav1.CreateOptions{})
+		return err
+	})
+	return action, err
+}
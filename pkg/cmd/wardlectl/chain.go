@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wardlectl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/clientexpansion"
+)
+
+func newCommandChain(ctx context.Context, o *WardlectlOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "chain NAME",
+		Short: "Follow the references of a Flunder",
+		Long: "Follow the references from the named Flunder through other Flunders of the namespace, " +
+			"until a Flunder without a reference, a Fischer, a missing object or a cycle is reached.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.chain(ctx, args[0])
+		},
+	}
+}
+
+func (o *WardlectlOptions) chain(ctx context.Context, name string) error {
+	chain, err := clientexpansion.ResolveChain[*wardle.Flunder](ctx, flunderGetter{o}, name, referencedFlunder)
+	prefix := ""
+	for _, flunder := range chain {
+		if err := o.printLink(prefix, "flunder/"+flunder.Name, ""); err != nil {
+			return err
+		}
+		prefix = "-> "
+	}
+	if err != nil {
+		// the chain ends before the first object it could not resolve
+		unresolved := name
+		if len(chain) != 0 {
+			unresolved = referencedFlunder(chain[len(chain)-1])
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+			return o.printLink(prefix, "flunder/"+unresolved, "missing")
+		case slices.ContainsFunc(chain, func(flunder *wardle.Flunder) bool { return flunder.Name == unresolved }):
+			return o.printLink(prefix, "flunder/"+unresolved, "cycle")
+		default:
+			return err
+		}
+	}
+
+	last := chain[len(chain)-1]
+	if last.Spec.ReferenceType != wardle.FischerReferenceType {
+		return nil
+	}
+	_, err = o.getFischer(ctx, last.Spec.FischerReference)
+	if apierrors.IsNotFound(err) {
+		return o.printLink(prefix, "fischer/"+last.Spec.FischerReference, "missing")
+	}
+	if err != nil {
+		return err
+	}
+	return o.printLink(prefix, "fischer/"+last.Spec.FischerReference, "")
+}
+
+// referencedFlunder returns the name of the Flunder referenced by flunder, or
+// an empty name if it does not reference a Flunder.
+func referencedFlunder(flunder *wardle.Flunder) string {
+	if flunder.Spec.ReferenceType != wardle.FlunderReferenceType {
+		return ""
+	}
+	return flunder.Spec.FlunderReference
+}
+
+// flunderGetter gets Flunders in the API version of the options.
+type flunderGetter struct {
+	o *WardlectlOptions
+}
+
+func (g flunderGetter) Get(ctx context.Context, name string, opts metav1.GetOptions) (*wardle.Flunder, error) {
+	return g.o.getFlunder(ctx, name)
+}
+
+func (o *WardlectlOptions) printLink(prefix, object, problem string) error {
+	if len(problem) != 0 {
+		object += " " + o.colorize(red, "("+problem+")")
+	}
+	_, err := fmt.Fprintf(o.StdOut, "%s%s\n", prefix, object)
+	return err
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wardlectl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+	"k8s.io/sample-apiserver/pkg/apiserver"
+	"sigs.k8s.io/yaml"
+)
+
+// convertOptions contains the options of the convert command.
+type convertOptions struct {
+	*WardlectlOptions
+
+	// Filenames are the manifests to convert, "-" for standard input.
+	Filenames []string
+	// OutputVersion is the wardle API version the manifests are converted to.
+	OutputVersion string
+
+	StdIn io.Reader
+}
+
+func newCommandConvert(parent *WardlectlOptions) *cobra.Command {
+	o := &convertOptions{WardlectlOptions: parent, OutputVersion: v1beta1.SchemeGroupVersion.Version}
+	cmd := &cobra.Command{
+		Use:   "convert -f FILENAME",
+		Short: "Convert manifests of wardle objects to another API version without a server",
+		Long: "Convert the wardle objects in multi-document YAML or JSON manifests to the output version, " +
+			"applying the defaults of their API version first. Documents are written in their original order " +
+			"and format. Comments before a YAML document are kept, other documents are copied unchanged.",
+		// convert works offline, so it does not connect to the server like
+		// the other commands do.
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+			return nil
+		},
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			o.StdIn = c.InOrStdin()
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&o.Filenames, "filename", "f", o.Filenames, "The manifests to convert, - for standard input.")
+	flags.StringVar(&o.OutputVersion, "output-version", o.OutputVersion, "The wardle API version to convert to, v1alpha1 or v1beta1.")
+
+	return cmd
+}
+
+// Validate validates convertOptions
+func (o *convertOptions) Validate() error {
+	if len(o.Filenames) == 0 {
+		return fmt.Errorf("at least one --filename is required")
+	}
+	switch o.OutputVersion {
+	case v1alpha1.SchemeGroupVersion.Version, v1beta1.SchemeGroupVersion.Version:
+		return nil
+	default:
+		return fmt.Errorf("unsupported --output-version %q, must be %s or %s", o.OutputVersion, v1alpha1.SchemeGroupVersion.Version, v1beta1.SchemeGroupVersion.Version)
+	}
+}
+
+// Run converts the manifests and writes them to StdOut.
+func (o *convertOptions) Run() error {
+	for i, filename := range o.Filenames {
+		var data []byte
+		var err error
+		if filename == "-" {
+			data, err = io.ReadAll(o.StdIn)
+		} else {
+			data, err = os.ReadFile(filename)
+		}
+		if err != nil {
+			return err
+		}
+
+		converted, err := o.convert(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+		if i > 0 && !isJSON(data) {
+			converted = append([]byte("---\n"), converted...)
+		}
+		if _, err := o.StdOut.Write(converted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convert converts the documents of a YAML or JSON stream.
+func (o *convertOptions) convert(data []byte) ([]byte, error) {
+	if isJSON(data) {
+		return o.convertJSON(data)
+	}
+	return o.convertYAML(data)
+}
+
+func isJSON(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	return len(trimmed) != 0 && trimmed[0] == '{'
+}
+
+func (o *convertOptions) convertJSON(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for i := 0; ; i++ {
+		var document json.RawMessage
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			return out.Bytes(), nil
+		} else if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+
+		converted, err := o.convertDocument(document, marshalIndentJSON)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		out.Write(converted)
+		if !bytes.HasSuffix(converted, []byte("\n")) {
+			out.WriteByte('\n')
+		}
+	}
+}
+
+func (o *convertOptions) convertYAML(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for i := 0; ; i++ {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return out.Bytes(), nil
+		} else if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+
+		comments, body := splitLeadingComments(document)
+		converted := body
+		if len(bytes.TrimSpace(body)) != 0 {
+			converted, err = o.convertDocument(body, yaml.Marshal)
+			if err != nil {
+				return nil, fmt.Errorf("document %d: %w", i, err)
+			}
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(comments)
+		out.Write(converted)
+		if len(converted) != 0 && !bytes.HasSuffix(converted, []byte("\n")) {
+			out.WriteByte('\n')
+		}
+	}
+}
+
+// splitLeadingComments splits the comment and blank lines at the beginning of
+// a YAML document from the rest of the document.
+func splitLeadingComments(document []byte) ([]byte, []byte) {
+	offset := 0
+	for offset < len(document) {
+		end := bytes.IndexByte(document[offset:], '\n')
+		if end < 0 {
+			end = len(document) - offset - 1
+		}
+		line := strings.TrimSpace(string(document[offset : offset+end+1]))
+		if len(line) != 0 && !strings.HasPrefix(line, "#") {
+			break
+		}
+		offset += end + 1
+	}
+	return document[:offset], document[offset:]
+}
+
+func marshalIndentJSON(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
+
+// convertDocument decodes a wardle object with the universal decoder, which
+// applies the defaults of its version (e.g. SetDefaults_FlunderSpec) on the
+// way to the internal version, and marshals it in the output version. Other
+// objects are returned unchanged.
+func (o *convertOptions) convertDocument(document []byte, marshal func(interface{}) ([]byte, error)) ([]byte, error) {
+	obj, gvk, err := apiserver.Codecs.UniversalDecoder().Decode(document, nil, nil)
+	if runtime.IsNotRegisteredError(err) || (err == nil && gvk.Group != wardle.GroupName) {
+		return document, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	target := schema.GroupVersion{Group: wardle.GroupName, Version: o.OutputVersion}
+	if !apiserver.Scheme.Recognizes(target.WithKind(gvk.Kind)) {
+		fmt.Fprintf(o.StdErr, "warning: %s is not served in %s, keeping %s\n", gvk.Kind, target, gvk.GroupVersion())
+		return document, nil
+	}
+	info, _ := runtime.SerializerInfoForMediaType(apiserver.Codecs.SupportedMediaTypes(), runtime.ContentTypeJSON)
+	data, err := runtime.Encode(apiserver.Codecs.EncoderForVersion(info.Serializer, target), obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop the fields a manifest does not have but the encoder writes anyway.
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if timestamp, _, _ := unstructured.NestedFieldNoCopy(fields, "metadata", "creationTimestamp"); timestamp == nil {
+		unstructured.RemoveNestedField(fields, "metadata", "creationTimestamp")
+	}
+	if status, ok := fields["status"].(map[string]interface{}); ok && len(status) == 0 {
+		delete(fields, "status")
+	}
+	return marshal(fields)
+}
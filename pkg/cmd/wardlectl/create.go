@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wardlectl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+)
+
+func newCommandCreate(ctx context.Context, o *WardlectlOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create wardle objects",
+	}
+	cmd.AddCommand(newCommandCreateFlunder(ctx, o))
+	return cmd
+}
+
+// createFlunderOptions contains the options of the create flunder command.
+type createFlunderOptions struct {
+	*WardlectlOptions
+
+	FlunderReference string
+	FischerReference string
+	// Owned makes the referenced Flunder the owner of the new Flunder.
+	Owned bool
+}
+
+func newCommandCreateFlunder(ctx context.Context, parent *WardlectlOptions) *cobra.Command {
+	o := &createFlunderOptions{WardlectlOptions: parent}
+	cmd := &cobra.Command{
+		Use:   "flunder NAME",
+		Short: "Create a Flunder, optionally referencing another Flunder or a Fischer",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run(ctx, args[0])
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&o.FlunderReference, "flunder-reference", o.FlunderReference, "The name of a Flunder in the same namespace the new Flunder references.")
+	flags.StringVar(&o.FischerReference, "fischer-reference", o.FischerReference, "The name of a Fischer the new Flunder references.")
+	flags.BoolVar(&o.Owned, "owned", o.Owned, "Make the Flunder of --flunder-reference the owner of the new Flunder, so that deleting it deletes the new Flunder too.")
+
+	return cmd
+}
+
+// Validate validates createFlunderOptions
+func (o *createFlunderOptions) Validate() error {
+	if len(o.FlunderReference) != 0 && len(o.FischerReference) != 0 {
+		return fmt.Errorf("--flunder-reference and --fischer-reference are mutually exclusive")
+	}
+	if o.Owned && len(o.FlunderReference) == 0 {
+		return fmt.Errorf("--owned requires --flunder-reference")
+	}
+	return nil
+}
+
+// Run creates the Flunder in the API version of the options.
+func (o *createFlunderOptions) Run(ctx context.Context, name string) error {
+	meta := metav1.ObjectMeta{Name: name, Namespace: o.Namespace}
+	switch o.APIVersion {
+	case v1beta1.SchemeGroupVersion.Version:
+		flunder := &v1beta1.Flunder{
+			ObjectMeta: meta,
+			Spec: v1beta1.FlunderSpec{
+				FlunderReference: o.FlunderReference,
+				FischerReference: o.FischerReference,
+			},
+		}
+		switch {
+		case len(o.FlunderReference) != 0:
+			flunder.Spec.ReferenceType = v1beta1.FlunderReferenceType
+			if o.Owned {
+				flunder.Spec.OwnershipPolicy = v1beta1.OwnedByReferenceOwnershipPolicy
+			}
+		case len(o.FischerReference) != 0:
+			flunder.Spec.ReferenceType = v1beta1.FischerReferenceType
+		}
+		if _, err := o.ClientSet.WardleV1beta1().Flunders(o.Namespace).Create(ctx, flunder, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	default:
+		flunder := &v1alpha1.Flunder{ObjectMeta: meta}
+		switch {
+		case len(o.FlunderReference) != 0:
+			referenceType := v1alpha1.FlunderReferenceType
+			flunder.Spec = v1alpha1.FlunderSpec{Reference: o.FlunderReference, ReferenceType: &referenceType}
+			if o.Owned {
+				flunder.Spec.OwnershipPolicy = v1alpha1.OwnedByReferenceOwnershipPolicy
+			}
+		case len(o.FischerReference) != 0:
+			referenceType := v1alpha1.FischerReferenceType
+			flunder.Spec = v1alpha1.FlunderSpec{Reference: o.FischerReference, ReferenceType: &referenceType}
+		}
+		if _, err := o.ClientSet.WardleV1alpha1().Flunders(o.Namespace).Create(ctx, flunder, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(o.StdOut, "flunder/%s created\n", name)
+	return err
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wardlectl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+)
+
+func newCommandExplain(ctx context.Context, o *WardlectlOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain NAME",
+		Short: "Explain whether and why a Flunder name is banned in the namespace",
+		Long: "Explain whether a Flunder with the given name would be banned in the namespace, " +
+			"and list the Fischers and FlunderPolicies banning it. The server answers with a FlunderBanReview, " +
+			"which is only available in v1alpha1.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.explain(ctx, args[0])
+		},
+	}
+	return cmd
+}
+
+func (o *WardlectlOptions) explain(ctx context.Context, name string) error {
+	review, err := o.ClientSet.WardleV1alpha1().FlunderBanReviews().Create(ctx, &v1alpha1.FlunderBanReview{
+		Spec: v1alpha1.FlunderBanReviewSpec{Name: name, Namespace: o.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	if !review.Status.Banned {
+		_, err := fmt.Fprintf(o.StdOut, "%s is not banned in namespace %s\n", name, o.Namespace)
+		return err
+	}
+	if _, err := fmt.Fprintf(o.StdOut, "%s is %s in namespace %s:\n", name, o.colorize(red, "banned"), o.Namespace); err != nil {
+		return err
+	}
+	for _, ban := range review.Status.Bans {
+		var err error
+		if len(ban.FlunderPolicy) != 0 {
+			_, err = fmt.Fprintf(o.StdOut, "  FlunderPolicy %s disallows %q\n", ban.FlunderPolicy, ban.Entry)
+		} else {
+			_, err = fmt.Fprintf(o.StdOut, "  Fischer %s disallows %q\n", ban.Fischer, ban.Entry)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wardlectl
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// writeJUnit writes the results as JUnit XML, with a test suite per file and
+// a test case per object. All problems of an object are reported in one
+// failure, whose type is the rule of the first problem.
+func writeJUnit(w io.Writer, results []result) error {
+	report := junitTestSuites{}
+	suites := map[string]int{}
+	for _, r := range results {
+		i, found := suites[r.File]
+		if !found {
+			i = len(report.Suites)
+			suites[r.File] = i
+			report.Suites = append(report.Suites, junitTestSuite{Name: r.File})
+		}
+		suite := &report.Suites[i]
+
+		testCase := junitTestCase{Name: r.String(), ClassName: r.File}
+		if len(r.Problems) != 0 {
+			lines := make([]string, 0, len(r.Problems))
+			for _, p := range r.Problems {
+				lines = append(lines, fmt.Sprintf("%s:%d: %s", r.File, r.Line, p))
+			}
+			testCase.Failure = &junitFailure{
+				Message: r.Problems[0].String(),
+				Type:    r.Problems[0].Rule,
+				Text:    strings.Join(lines, "\n"),
+			}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// sarifRules describes the rules of the problems.
+var sarifRules = []sarifRule{
+	{ID: ruleUnreadable, ShortDescription: sarifMessage{Text: "The document cannot be decoded as a wardle object."}},
+	{ID: ruleInvalid, ShortDescription: sarifMessage{Text: "The object is rejected by the validation of the wardle server."}},
+	{ID: ruleBanned, ShortDescription: sarifMessage{Text: "The name of the Flunder is banned by a Fischer or FlunderPolicy."}},
+}
+
+// writeSARIF writes the problems as a SARIF 2.1.0 log. Each result points at
+// the first line of the document of the object, and names the object and the
+// field path as its logical location.
+func writeSARIF(w io.Writer, results []result) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "wardlectl", Rules: sarifRules}},
+		Results: []sarifResult{},
+	}
+	for _, r := range results {
+		for _, p := range r.Problems {
+			location := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: r.File},
+					Region:           sarifRegion{StartLine: r.Line},
+				},
+			}
+			if len(p.Field) != 0 {
+				location.LogicalLocations = []sarifLogicalLocation{{
+					Name:               p.Field,
+					FullyQualifiedName: r.String() + " " + p.Field,
+					Kind:               "member",
+				}}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    p.Rule,
+				Level:     "error",
+				Message:   sarifMessage{Text: r.String() + ": " + p.String()},
+				Locations: []sarifLocation{location},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,428 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wardlectl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/validation"
+	"k8s.io/sample-apiserver/pkg/apiserver"
+	"k8s.io/sample-apiserver/pkg/banning"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// formatJUnit writes a JUnit XML report with one test suite per file and
+	// one test case per object.
+	formatJUnit = "junit"
+	// formatSARIF writes a SARIF 2.1.0 log with one result per problem.
+	formatSARIF = "sarif"
+)
+
+// Rules of the problems reported by validate.
+const (
+	ruleUnreadable = "unreadable"
+	ruleInvalid    = "invalid"
+	ruleBanned     = "banned"
+)
+
+// validateOptions contains the options of the validate command.
+type validateOptions struct {
+	*WardlectlOptions
+
+	// Snapshot is a manifest with further Fischers, e.g. the output of
+	// kubectl get fischers -o yaml.
+	Snapshot string
+	// Format is the format of the report, junit or sarif.
+	Format string
+}
+
+func newCommandValidate(parent *WardlectlOptions) *cobra.Command {
+	o := &validateOptions{WardlectlOptions: parent, Format: formatJUnit}
+	cmd := &cobra.Command{
+		Use:   "validate DIRECTORY",
+		Short: "Validate the manifests of wardle objects in a directory without a server",
+		Long: "Validate the Flunders, Fischers and FlunderPolicies in the YAML and JSON manifests of a directory " +
+			"like the server does, and check that no Fischer or FlunderPolicy of the directory or of the " +
+			"--fischers snapshot bans a Flunder. A JUnit or SARIF report is written to the standard output, " +
+			"and the command fails if any problem is found.",
+		// validate works offline, so it does not connect to the server like
+		// the other commands do.
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run(args[0])
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&o.Snapshot, "fischers", o.Snapshot, "A manifest with further Fischers, e.g. the output of kubectl get fischers -o yaml.")
+	flags.StringVar(&o.Format, "format", o.Format, "The format of the report, junit or sarif.")
+
+	return cmd
+}
+
+// Validate validates validateOptions
+func (o *validateOptions) Validate() error {
+	switch o.Format {
+	case formatJUnit, formatSARIF:
+		return nil
+	default:
+		return fmt.Errorf("unsupported --format %q, must be %s or %s", o.Format, formatJUnit, formatSARIF)
+	}
+}
+
+// manifestObject is a wardle object decoded from a manifest.
+type manifestObject struct {
+	File string
+	// Line is the first line of the document of the object.
+	Line int
+	// Object is the decoded object in the internal version.
+	Object runtime.Object
+	// Err is the error decoding the document if Object is nil.
+	Err error
+}
+
+// String returns the kind, namespace and name of the object.
+func (m manifestObject) String() string {
+	if m.Object == nil {
+		return fmt.Sprintf("document at line %d", m.Line)
+	}
+	kind := strings.TrimPrefix(fmt.Sprintf("%T", m.Object), "*wardle.")
+	accessor, err := meta.Accessor(m.Object)
+	if err != nil {
+		return kind
+	}
+	if len(accessor.GetNamespace()) != 0 {
+		return fmt.Sprintf("%s %s/%s", kind, accessor.GetNamespace(), accessor.GetName())
+	}
+	return fmt.Sprintf("%s %s", kind, accessor.GetName())
+}
+
+// problem is a problem found in a manifest.
+type problem struct {
+	Rule string
+	// Field is the path of the field with the problem, if any.
+	Field   string
+	Message string
+}
+
+// String returns the field path and the message of the problem.
+func (p problem) String() string {
+	if len(p.Field) == 0 {
+		return p.Message
+	}
+	return p.Field + ": " + p.Message
+}
+
+// result is the outcome of validating one object.
+type result struct {
+	manifestObject
+	Problems []problem
+}
+
+// Run validates the manifests in the directory and writes the report.
+func (o *validateOptions) Run(dir string) error {
+	var objects []manifestObject
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		fileObjects, err := readManifest(path)
+		objects = append(objects, fileObjects...)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	var snapshot []manifestObject
+	if len(o.Snapshot) != 0 {
+		if snapshot, err = readManifest(o.Snapshot); err != nil {
+			return err
+		}
+		for _, object := range snapshot {
+			if object.Err != nil {
+				return fmt.Errorf("%s:%d: %w", object.File, object.Line, object.Err)
+			}
+		}
+	}
+
+	results, err := o.validate(objects, snapshot)
+	if err != nil {
+		return err
+	}
+
+	switch o.Format {
+	case formatSARIF:
+		err = writeSARIF(o.StdOut, results)
+	default:
+		err = writeJUnit(o.StdOut, results)
+	}
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, r := range results {
+		count += len(r.Problems)
+	}
+	if count != 0 {
+		return fmt.Errorf("found %d problems in %s", count, dir)
+	}
+	return nil
+}
+
+// validate validates the objects and checks that Flunders are not banned by
+// the Fischers and FlunderPolicies of the objects or the Fischers of the
+// snapshot.
+func (o *validateOptions) validate(objects, snapshot []manifestObject) ([]result, error) {
+	var fischers []*v1alpha1.Fischer
+	var policies []*v1alpha1.FlunderPolicy
+	for _, object := range append(objects[:len(objects):len(objects)], snapshot...) {
+		switch obj := object.Object.(type) {
+		case *wardle.Fischer:
+			fischer := &v1alpha1.Fischer{}
+			if err := apiserver.Scheme.Convert(obj, fischer, nil); err != nil {
+				return nil, err
+			}
+			fischers = append(fischers, fischer)
+		case *wardle.FlunderPolicy:
+			policy := &v1alpha1.FlunderPolicy{}
+			if err := apiserver.Scheme.Convert(obj, policy, nil); err != nil {
+				return nil, err
+			}
+			if len(policy.Namespace) == 0 {
+				policy.Namespace = o.namespace()
+			}
+			policies = append(policies, policy)
+		}
+	}
+
+	var results []result
+	for _, object := range objects {
+		r := result{manifestObject: object}
+		if object.Err != nil {
+			r.Problems = append(r.Problems, problem{Rule: ruleUnreadable, Message: object.Err.Error()})
+		}
+		var errs field.ErrorList
+		var bans []banning.Ban
+		switch obj := object.Object.(type) {
+		case *wardle.Flunder:
+			errs = validation.ValidateFlunder(obj)
+			namespace := obj.Namespace
+			if len(namespace) == 0 {
+				namespace = o.namespace()
+			}
+			bans = banning.Bans(fischers, policies, banning.Attributes{
+				Name:      obj.Name,
+				Namespace: namespace,
+			})
+		case *wardle.Fischer:
+			errs = validation.ValidateFischer(obj)
+		case *wardle.FlunderPolicy:
+			errs = validation.ValidateFlunderPolicy(obj)
+		}
+		for _, err := range errs {
+			r.Problems = append(r.Problems, problem{Rule: ruleInvalid, Field: err.Field, Message: err.ErrorBody()})
+		}
+		for _, ban := range bans {
+			message := fmt.Sprintf("Fischer %q disallows %q", ban.Fischer, ban.Entry)
+			if len(ban.FlunderPolicy) != 0 {
+				message = fmt.Sprintf("FlunderPolicy %q disallows %q", ban.FlunderPolicy, ban.Entry)
+			}
+			r.Problems = append(r.Problems, problem{Rule: ruleBanned, Field: "metadata.name", Message: message})
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func (o *validateOptions) namespace() string {
+	if len(o.Namespace) == 0 {
+		return "default"
+	}
+	return o.Namespace
+}
+
+// readManifest decodes the Flunders, Fischers and FlunderPolicies of a YAML or
+// JSON manifest, including the items of lists. Documents which cannot be
+// decoded are returned with their error, other objects are skipped.
+func readManifest(path string) ([]manifestObject, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := splitDocuments(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	file := filepath.ToSlash(path)
+	var objects []manifestObject
+	for _, document := range documents {
+		decoded, err := decodeWardleObjects(document.Data)
+		if err != nil {
+			objects = append(objects, manifestObject{File: file, Line: document.Line, Err: err})
+			continue
+		}
+		for _, obj := range decoded {
+			objects = append(objects, manifestObject{File: file, Line: document.Line, Object: obj})
+		}
+	}
+	return objects, nil
+}
+
+// decodeWardleObjects decodes a document to the internal version, applying
+// the defaults of its version. The items of lists are decoded one by one, and
+// objects which are neither Flunders, Fischers nor FlunderPolicies are
+// dropped.
+func decodeWardleObjects(document []byte) ([]runtime.Object, error) {
+	data, err := yaml.YAMLToJSON(document)
+	if err != nil {
+		return nil, err
+	}
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(data, &typeMeta); err != nil {
+		return nil, err
+	}
+
+	// kubectl get -o yaml returns a List of the core group, which the wardle
+	// scheme does not know.
+	if typeMeta.Kind == "List" {
+		list := &unstructured.UnstructuredList{}
+		if err := list.UnmarshalJSON(data); err != nil {
+			return nil, err
+		}
+		var objects []runtime.Object
+		for _, item := range list.Items {
+			itemData, err := item.MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			itemObjects, err := decodeWardleObjects(itemData)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, itemObjects...)
+		}
+		return objects, nil
+	}
+
+	if gv, err := schema.ParseGroupVersion(typeMeta.APIVersion); err != nil || gv.Group != wardle.GroupName {
+		return nil, nil
+	}
+	obj, _, err := apiserver.Codecs.UniversalDecoder().Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch obj := obj.(type) {
+	case *wardle.Flunder, *wardle.Fischer, *wardle.FlunderPolicy:
+		return []runtime.Object{obj}, nil
+	case *wardle.FlunderList, *wardle.FischerList, *wardle.FlunderPolicyList:
+		return meta.ExtractList(obj)
+	default:
+		return nil, nil
+	}
+}
+
+// document is a document of a YAML or JSON stream.
+type document struct {
+	// Line is the first line of the document.
+	Line int
+	Data []byte
+}
+
+// splitDocuments splits a multi-document YAML stream at its --- separators in
+// the first column, like kubectl, or a stream of JSON objects, skipping empty
+// documents.
+func splitDocuments(data []byte) ([]document, error) {
+	var documents []document
+	if isJSON(data) {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		for {
+			offset := decoder.InputOffset()
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+				return documents, nil
+			} else if err != nil {
+				return nil, err
+			}
+			leading := len(data[offset:]) - len(bytes.TrimLeft(data[offset:], " \t\r\n"))
+			line := bytes.Count(data[:int(offset)+leading], []byte("\n")) + 1
+			documents = append(documents, document{Line: line, Data: raw})
+		}
+	}
+
+	// the YAML reader splits at --- separators in the first column. It
+	// returns every line of the stream as a line of a document, except the
+	// separator ending a document, and separators without a document before
+	// them start the next document.
+	next := 1
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		raw, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		} else if err != nil {
+			return nil, err
+		}
+		line := next
+		next += bytes.Count(raw, []byte("\n")) + 1
+
+		// the document starts at its first line which is neither empty, a
+		// comment nor a separator, documents without such a line are skipped
+		for _, l := range bytes.SplitAfter(raw, []byte("\n")) {
+			trimmed := bytes.TrimSpace(l)
+			if len(trimmed) != 0 && !bytes.HasPrefix(trimmed, []byte("#")) && !bytes.HasPrefix(l, []byte("---")) {
+				documents = append(documents, document{Line: line, Data: raw})
+				break
+			}
+			line++
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package wardlectl implements a command-line client for the wardle API.
+package wardlectl
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+	"k8s.io/sample-apiserver/pkg/apiserver"
+	clientset "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
+)
+
+// WardlectlOptions contains the options shared by all wardlectl commands.
+type WardlectlOptions struct {
+	// Kubeconfig is the kubeconfig file of the wardle server. Defaults to the
+	// loading rules of kubectl.
+	Kubeconfig string
+	// Context is the kubeconfig context to use. Defaults to the current
+	// context.
+	Context string
+	// Namespace is the namespace of Flunders. Defaults to the namespace of
+	// the kubeconfig context.
+	Namespace string
+	// APIVersion is the version of the wardle API the commands use, v1alpha1
+	// or v1beta1.
+	APIVersion string
+	// Color enables colored output.
+	Color bool
+
+	// ClientSet is used instead of a clientset for the kubeconfig if it is
+	// set.
+	ClientSet clientset.Interface
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewWardlectlOptions returns the default options.
+func NewWardlectlOptions(out, errOut io.Writer) *WardlectlOptions {
+	return &WardlectlOptions{
+		APIVersion: v1alpha1.SchemeGroupVersion.Version,
+		Color:      true,
+		StdOut:     out,
+		StdErr:     errOut,
+	}
+}
+
+// NewCommandWardlectl provides a CLI handler for wardlectl and its
+// subcommands.
+func NewCommandWardlectl(ctx context.Context, defaults *WardlectlOptions) *cobra.Command {
+	o := *defaults
+	cmd := &cobra.Command{
+		Use:   "wardlectl",
+		Short: "Manage Flunders and Fischers of a wardle server",
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Complete()
+		},
+	}
+	cmd.SetOut(o.StdOut)
+	cmd.SetErr(o.StdErr)
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "The kubeconfig file of the wardle server.")
+	flags.StringVar(&o.Context, "context", o.Context, "The kubeconfig context to use.")
+	flags.StringVarP(&o.Namespace, "namespace", "n", o.Namespace, "The namespace of Flunders. Defaults to the namespace of the kubeconfig context.")
+	flags.StringVar(&o.APIVersion, "api-version", o.APIVersion, "The version of the wardle API to use, v1alpha1 or v1beta1.")
+	flags.BoolVar(&o.Color, "color", o.Color, "Colorize the output.")
+
+	cmd.AddCommand(
+		newCommandCreate(ctx, &o),
+		newCommandBan(ctx, &o),
+		newCommandUnban(ctx, &o),
+		newCommandExplain(ctx, &o),
+		newCommandChain(ctx, &o),
+		newCommandWatch(ctx, &o),
+		newCommandConvert(&o),
+		newCommandValidate(&o),
+	)
+	return cmd
+}
+
+// Validate validates WardlectlOptions
+func (o *WardlectlOptions) Validate() error {
+	switch o.APIVersion {
+	case v1alpha1.SchemeGroupVersion.Version, v1beta1.SchemeGroupVersion.Version:
+		return nil
+	default:
+		return fmt.Errorf("unsupported --api-version %q, must be %s or %s", o.APIVersion, v1alpha1.SchemeGroupVersion.Version, v1beta1.SchemeGroupVersion.Version)
+	}
+}
+
+// Complete creates the clientset for the kubeconfig and defaults the
+// namespace.
+func (o *WardlectlOptions) Complete() error {
+	if o.ClientSet != nil {
+		if len(o.Namespace) == 0 {
+			o.Namespace = "default"
+		}
+		return nil
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = o.Kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: o.Context}
+	overrides.Context.Namespace = o.Namespace
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	o.Namespace = namespace
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	o.ClientSet, err = clientset.NewForConfig(config)
+	return err
+}
+
+// toInternal converts a Flunder or Fischer of any wardle version to its
+// internal type.
+func toInternal(in, out interface{}) error {
+	return apiserver.Scheme.Convert(in, out, nil)
+}
+
+// getFlunder returns the Flunder with the given name in the API version of
+// the options, converted to the internal type.
+func (o *WardlectlOptions) getFlunder(ctx context.Context, name string) (*wardle.Flunder, error) {
+	var versioned interface{}
+	var err error
+	switch o.APIVersion {
+	case v1beta1.SchemeGroupVersion.Version:
+		versioned, err = o.ClientSet.WardleV1beta1().Flunders(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	default:
+		versioned, err = o.ClientSet.WardleV1alpha1().Flunders(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	flunder := &wardle.Flunder{}
+	if err := toInternal(versioned, flunder); err != nil {
+		return nil, err
+	}
+	return flunder, nil
+}
+
+// getFischer returns the Fischer with the given name in the API version of
+// the options, converted to the internal type.
+func (o *WardlectlOptions) getFischer(ctx context.Context, name string) (*wardle.Fischer, error) {
+	var versioned interface{}
+	var err error
+	switch o.APIVersion {
+	case v1beta1.SchemeGroupVersion.Version:
+		versioned, err = o.ClientSet.WardleV1beta1().Fischers().Get(ctx, name, metav1.GetOptions{})
+	default:
+		versioned, err = o.ClientSet.WardleV1alpha1().Fischers().Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	fischer := &wardle.Fischer{}
+	if err := toInternal(versioned, fischer); err != nil {
+		return nil, err
+	}
+	return fischer, nil
+}
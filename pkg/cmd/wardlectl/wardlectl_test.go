@@ -0,0 +1,477 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wardlectl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/fake"
+)
+
+// lockedBuffer is a bytes.Buffer that can be read while a command writes it.
+type lockedBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
+
+func run(ctx context.Context, cs *fake.Clientset, out *lockedBuffer, args ...string) error {
+	o := NewWardlectlOptions(out, out)
+	o.ClientSet = cs
+	cmd := NewCommandWardlectl(ctx, o)
+	cmd.SetArgs(append([]string{"--color=false"}, args...))
+	return cmd.Execute()
+}
+
+func TestCreateFlunder(t *testing.T) {
+	ctx := context.Background()
+	cs := fake.NewSimpleClientset()
+
+	out := &lockedBuffer{}
+	require.NoError(t, run(ctx, cs, out, "create", "flunder", "alpha", "--fischer-reference", "fischer"))
+	assert.Equal(t, "flunder/alpha created\n", out.String())
+	alpha, err := cs.WardleV1alpha1().Flunders("default").Get(ctx, "alpha", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "fischer", alpha.Spec.Reference)
+	require.NotNil(t, alpha.Spec.ReferenceType)
+	assert.Equal(t, v1alpha1.FischerReferenceType, *alpha.Spec.ReferenceType)
+
+	require.NoError(t, run(ctx, cs, &lockedBuffer{}, "create", "flunder", "beta", "-n", "ns", "--api-version", "v1beta1", "--flunder-reference", "alpha", "--owned"))
+	beta, err := cs.WardleV1beta1().Flunders("ns").Get(ctx, "beta", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, v1beta1.FlunderSpec{
+		FlunderReference: "alpha",
+		ReferenceType:    v1beta1.FlunderReferenceType,
+		OwnershipPolicy:  v1beta1.OwnedByReferenceOwnershipPolicy,
+	}, beta.Spec)
+
+	assert.Error(t, run(ctx, cs, &lockedBuffer{}, "create", "flunder", "gamma", "--flunder-reference", "a", "--fischer-reference", "b"))
+	assert.Error(t, run(ctx, cs, &lockedBuffer{}, "create", "flunder", "gamma", "--api-version", "v1"))
+	assert.Error(t, run(ctx, cs, &lockedBuffer{}, "create", "flunder", "gamma", "--fischer-reference", "b", "--owned"))
+}
+
+func TestBanUnban(t *testing.T) {
+	ctx := context.Background()
+	cs := fake.NewSimpleClientset()
+
+	out := &lockedBuffer{}
+	require.NoError(t, run(ctx, cs, out, "ban", "fischer", "a", "b"))
+	require.NoError(t, run(ctx, cs, out, "ban", "fischer", "b", "c"))
+	require.NoError(t, run(ctx, cs, out, "unban", "fischer", "a"))
+	assert.Equal(t, "fischer/fischer created\nfischer/fischer updated\nfischer/fischer updated\n", out.String())
+
+	fischer, err := cs.WardleV1alpha1().Fischers().Get(ctx, "fischer", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, fischer.DisallowedFlunders)
+
+	assert.Error(t, run(ctx, cs, &lockedBuffer{}, "unban", "missing", "a"), "unban must not create Fischers")
+}
+
+func TestBanCreateRace(t *testing.T) {
+	ctx := context.Background()
+	cs := fake.NewSimpleClientset()
+	// another client creates the Fischer between the get and the create
+	raced := false
+	cs.PrependReactor("create", "fischers", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if raced {
+			return false, nil, nil
+		}
+		raced = true
+		other := &v1beta1.Fischer{ObjectMeta: metav1.ObjectMeta{Name: "fischer"}, DisallowedFlunders: []string{"other"}}
+		if err := cs.Tracker().Add(other); err != nil {
+			return true, nil, err
+		}
+		return true, nil, apierrors.NewAlreadyExists(v1beta1.Resource("fischers"), "fischer")
+	})
+
+	out := &lockedBuffer{}
+	require.NoError(t, run(ctx, cs, out, "ban", "fischer", "a", "--api-version", "v1beta1"))
+	assert.Equal(t, "fischer/fischer updated\n", out.String())
+	fischer, err := cs.WardleV1beta1().Fischers().Get(ctx, "fischer", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other", "a"}, fischer.DisallowedFlunders)
+}
+
+func TestExplain(t *testing.T) {
+	ctx := context.Background()
+	cs := fake.NewSimpleClientset()
+	cs.PrependReactor("create", "flunderbanreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*v1alpha1.FlunderBanReview)
+		if review.Spec.Name == "banned" && review.Spec.Namespace == "ns" {
+			review.Status.Banned = true
+			review.Status.Bans = []v1alpha1.FlunderBan{
+				{Fischer: "fischer", Entry: "banned"},
+				{FlunderPolicy: "policy", Entry: "ban*"},
+			}
+		}
+		return true, review, nil
+	})
+
+	out := &lockedBuffer{}
+	require.NoError(t, run(ctx, cs, out, "explain", "banned", "-n", "ns"))
+	assert.Equal(t, "banned is banned in namespace ns:\n"+
+		"  Fischer fischer disallows \"banned\"\n"+
+		"  FlunderPolicy policy disallows \"ban*\"\n", out.String())
+
+	out = &lockedBuffer{}
+	require.NoError(t, run(ctx, cs, out, "explain", "fine", "-n", "ns"))
+	assert.Equal(t, "fine is not banned in namespace ns\n", out.String())
+}
+
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+	flunderRef, fischerRef := v1alpha1.FlunderReferenceType, v1alpha1.FischerReferenceType
+	cs := fake.NewSimpleClientset(
+		&v1alpha1.Flunder{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"},
+			Spec:       v1alpha1.FlunderSpec{Reference: "b", ReferenceType: &flunderRef},
+		},
+		&v1alpha1.Flunder{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"},
+			Spec:       v1alpha1.FlunderSpec{Reference: "fischer", ReferenceType: &fischerRef},
+		},
+		&v1alpha1.Flunder{
+			ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "default"},
+			Spec:       v1alpha1.FlunderSpec{Reference: "d", ReferenceType: &flunderRef},
+		},
+		&v1alpha1.Flunder{
+			ObjectMeta: metav1.ObjectMeta{Name: "d", Namespace: "default"},
+			Spec:       v1alpha1.FlunderSpec{Reference: "c", ReferenceType: &flunderRef},
+		},
+		&v1alpha1.Flunder{
+			ObjectMeta: metav1.ObjectMeta{Name: "e", Namespace: "default"},
+			Spec:       v1alpha1.FlunderSpec{Reference: "missing", ReferenceType: &flunderRef},
+		},
+	)
+
+	for name, expected := range map[string]string{
+		"a": "flunder/a\n-> flunder/b\n-> fischer/fischer (missing)\n",
+		"c": "flunder/c\n-> flunder/d\n-> flunder/c (cycle)\n",
+		"e": "flunder/e\n-> flunder/missing (missing)\n",
+	} {
+		out := &lockedBuffer{}
+		require.NoError(t, run(ctx, cs, out, "chain", name))
+		assert.Equal(t, expected, out.String(), name)
+	}
+
+	_, err := cs.WardleV1alpha1().Fischers().Create(ctx, &v1alpha1.Fischer{ObjectMeta: metav1.ObjectMeta{Name: "fischer"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	out := &lockedBuffer{}
+	require.NoError(t, run(ctx, cs, out, "chain", "a"))
+	assert.Equal(t, "flunder/a\n-> flunder/b\n-> fischer/fischer\n", out.String())
+
+	// the Fischer is read in the API version of the options
+	_, err = cs.WardleV1beta1().Flunders("default").Create(ctx, &v1beta1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "beta"},
+		Spec:       v1beta1.FlunderSpec{FischerReference: "beta", ReferenceType: v1beta1.FischerReferenceType},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = cs.WardleV1beta1().Fischers().Create(ctx, &v1beta1.Fischer{ObjectMeta: metav1.ObjectMeta{Name: "beta"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	out = &lockedBuffer{}
+	require.NoError(t, run(ctx, cs, out, "chain", "beta", "--api-version", "v1beta1"))
+	assert.Equal(t, "flunder/beta\n-> fischer/beta\n", out.String())
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs := fake.NewSimpleClientset(&v1alpha1.Fischer{
+		ObjectMeta:         metav1.ObjectMeta{Name: "fischer"},
+		DisallowedFlunders: []string{"a"},
+	})
+	watching := make(chan struct{})
+	cs.PrependWatchReactor("fischers", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		close(watching)
+		return false, nil, nil
+	})
+
+	out := &lockedBuffer{}
+	done := make(chan error)
+	go func() {
+		done <- run(ctx, cs, out, "watch", "fischers")
+	}()
+	select {
+	case <-watching:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting for the watch")
+	}
+
+	fischer, err := cs.WardleV1alpha1().Fischers().Get(ctx, "fischer", metav1.GetOptions{})
+	require.NoError(t, err)
+	fischer.DisallowedFlunders = []string{"b"}
+	_, err = cs.WardleV1alpha1().Fischers().Update(ctx, fischer, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, cs.WardleV1alpha1().Fischers().Delete(ctx, "fischer", metav1.DeleteOptions{}))
+
+	expected := "ADDED fischer/fischer\n" +
+		"MODIFIED fischer/fischer\n" +
+		"  -- a\n" +
+		"  +- b\n" +
+		"DELETED fischer/fischer\n"
+	assert.Eventually(t, func() bool {
+		return out.String() == expected
+	}, wait.ForeverTestTimeout, 10*time.Millisecond, "got %q", out)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestDiffLines(t *testing.T) {
+	for _, tc := range []struct {
+		old, new string
+		expected []string
+	}{
+		{old: "a\nb\nc\n", new: "a\nb\nc\n"},
+		{old: "a\nb\nc\n", new: "a\nc\n", expected: []string{"-b"}},
+		{old: "a\nc\n", new: "a\nb\nc\n", expected: []string{"+b"}},
+		{old: "a\nb\nc\n", new: "a\nx\nc\nd\n", expected: []string{"-b", "+x", "+d"}},
+	} {
+		assert.Equal(t, tc.expected, diffLines(tc.old, tc.new), "%q -> %q", tc.old, tc.new)
+	}
+}
+
+func TestColorize(t *testing.T) {
+	o := NewWardlectlOptions(nil, nil)
+	assert.Equal(t, "\x1b[31mbanned\x1b[0m", o.colorize(red, "banned"))
+	o.Color = false
+	assert.Equal(t, "banned", o.colorize(red, "banned"))
+}
+
+func TestConvert(t *testing.T) {
+	manifests := filepath.Join(t.TempDir(), "manifests.yaml")
+	require.NoError(t, os.WriteFile(manifests, []byte(`# The first Flunder.
+apiVersion: wardle.example.com/v1alpha1
+kind: Flunder
+metadata:
+  name: a
+  namespace: ns
+spec:
+  reference: b
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns
+---
+# Policies are only served in v1alpha1.
+apiVersion: wardle.example.com/v1alpha1
+kind: FlunderPolicy
+metadata:
+  name: p
+`), 0644))
+
+	out, errOut := &lockedBuffer{}, &lockedBuffer{}
+	o := NewWardlectlOptions(out, errOut)
+	cmd := NewCommandWardlectl(context.Background(), o)
+	cmd.SetArgs([]string{"--kubeconfig", "/nonexistent", "convert", "-f", manifests, "-f", "-"})
+	cmd.SetIn(strings.NewReader(`{"apiVersion": "wardle.example.com/v1alpha1", "kind": "Fischer", "metadata": {"name": "f"}}`))
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, `# The first Flunder.
+apiVersion: wardle.example.com/v1beta1
+kind: Flunder
+metadata:
+  name: a
+  namespace: ns
+spec:
+  flunderReference: b
+  referenceType: Flunder
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns
+---
+# Policies are only served in v1alpha1.
+apiVersion: wardle.example.com/v1alpha1
+kind: FlunderPolicy
+metadata:
+  name: p
+{
+  "apiVersion": "wardle.example.com/v1beta1",
+  "kind": "Fischer",
+  "metadata": {
+    "name": "f"
+  }
+}
+`, out.String())
+	assert.Equal(t, "warning: FlunderPolicy is not served in wardle.example.com/v1beta1, keeping wardle.example.com/v1alpha1\n", errOut.String())
+
+	cmd = NewCommandWardlectl(context.Background(), NewWardlectlOptions(out, errOut))
+	cmd.SetArgs([]string{"convert", "-f", manifests, "--output-version", "v1"})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "flunders.yaml"), []byte(`# Fine.
+apiVersion: wardle.example.com/v1beta1
+kind: Flunder
+metadata:
+  name: good
+---
+apiVersion: wardle.example.com/v1beta1
+kind: Flunder
+metadata:
+  name: banned
+  namespace: ns
+spec:
+  flunderReference: a
+  fischerReference: b
+  referenceType: Flunder
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+---
+apiVersion: wardle.example.com/v1alpha1
+kind: Flunder
+metadata:
+  name: [broken
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fischer.json"), []byte(`{
+  "apiVersion": "wardle.example.com/v1alpha1",
+  "kind": "Fischer",
+  "metadata": {"name": "local"},
+  "disallowedFlunders": ["banned"]
+}`), 0644))
+	snapshot := filepath.Join(t.TempDir(), "fischers.yaml")
+	require.NoError(t, os.WriteFile(snapshot, []byte(`apiVersion: v1
+kind: List
+items:
+- apiVersion: wardle.example.com/v1alpha1
+  kind: Fischer
+  metadata:
+    name: remote
+  disallowedFlunders: [banned]
+`), 0644))
+
+	validate := func(format string) (string, error) {
+		out := &lockedBuffer{}
+		cmd := NewCommandWardlectl(context.Background(), NewWardlectlOptions(out, out))
+		cmd.SetArgs([]string{"--kubeconfig", "/nonexistent", "validate", dir, "--fischers", snapshot, "--format", format})
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := validate("sarif")
+	require.EqualError(t, err, "found 4 problems in "+dir)
+	log := sarifLog{}
+	require.NoError(t, json.Unmarshal([]byte(out), &log))
+	require.Len(t, log.Runs, 1)
+	type location struct {
+		rule, file, field string
+		line              int
+	}
+	var locations []location
+	for _, r := range log.Runs[0].Results {
+		l := location{rule: r.RuleID, file: filepath.Base(r.Locations[0].PhysicalLocation.ArtifactLocation.URI), line: r.Locations[0].PhysicalLocation.Region.StartLine}
+		if len(r.Locations[0].LogicalLocations) != 0 {
+			l.field = r.Locations[0].LogicalLocations[0].Name
+		}
+		locations = append(locations, l)
+	}
+	assert.Equal(t, []location{
+		{rule: ruleInvalid, file: "flunders.yaml", field: "spec.fischerReference", line: 7},
+		{rule: ruleBanned, file: "flunders.yaml", field: "metadata.name", line: 7},
+		{rule: ruleBanned, file: "flunders.yaml", field: "metadata.name", line: 7},
+		{rule: ruleUnreadable, file: "flunders.yaml", line: 22},
+	}, locations)
+	assert.Equal(t, `Flunder ns/banned: metadata.name: Fischer "remote" disallows "banned"`, log.Runs[0].Results[2].Message.Text)
+
+	out, err = validate("junit")
+	require.Error(t, err)
+	report := junitTestSuites{}
+	require.NoError(t, xml.Unmarshal([]byte(out), &report))
+	require.Len(t, report.Suites, 2)
+	assert.Equal(t, 1, report.Suites[0].Tests)
+	assert.Equal(t, 0, report.Suites[0].Failures)
+	assert.Equal(t, 3, report.Suites[1].Tests)
+	assert.Equal(t, 2, report.Suites[1].Failures)
+	assert.Equal(t, "Flunder good", report.Suites[1].Cases[0].Name)
+	assert.Nil(t, report.Suites[1].Cases[0].Failure)
+	require.NotNil(t, report.Suites[1].Cases[1].Failure)
+	assert.Equal(t, ruleInvalid, report.Suites[1].Cases[1].Failure.Type)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "flunders.yaml")))
+	_, err = validate("junit")
+	assert.NoError(t, err)
+	_, err = validate("text")
+	assert.Error(t, err)
+}
+
+func TestSplitDocuments(t *testing.T) {
+	data := "# leading comment\n" +
+		"---\n" +
+		"apiVersion: wardle.example.com/v1alpha1\n" +
+		"kind: Flunder\n" +
+		"metadata:\n" +
+		"  annotations:\n" +
+		"    notes: |\n" +
+		"      ---\n" +
+		"      not a separator\n" +
+		"---\n" +
+		"---\n" +
+		"\n" +
+		"# comment\n" +
+		"apiVersion: wardle.example.com/v1alpha1\n" +
+		"kind: Fischer\n" +
+		"--- # trailing\n"
+
+	documents, err := splitDocuments([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+	assert.Equal(t, 3, documents[0].Line)
+	assert.Contains(t, string(documents[0].Data), "      ---\n      not a separator\n")
+	assert.Equal(t, 14, documents[1].Line)
+	assert.Contains(t, string(documents[1].Data), "kind: Fischer\n")
+}
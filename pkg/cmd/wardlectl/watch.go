@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wardlectl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+	"sigs.k8s.io/yaml"
+)
+
+func newCommandWatch(ctx context.Context, o *WardlectlOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:       "watch [flunders|fischers]",
+		Short:     "Watch Flunders or Fischers and print their changes",
+		Long:      "Watch the Flunders of the namespace, or Fischers, and print every change as a diff of the object until interrupted.",
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{"flunders", "fischers"},
+		RunE: func(c *cobra.Command, args []string) error {
+			resource := "flunders"
+			if len(args) != 0 {
+				resource = args[0]
+			}
+			return o.watch(ctx, resource)
+		},
+	}
+}
+
+func (o *WardlectlOptions) watch(ctx context.Context, resource string) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(o.ClientSet, 0, informers.WithNamespace(o.Namespace))
+	versions := factory.Wardle()
+
+	var informer cache.SharedIndexInformer
+	kind := strings.TrimSuffix(resource, "s")
+	switch {
+	case o.APIVersion == v1beta1.SchemeGroupVersion.Version && resource == "fischers":
+		informer = versions.V1beta1().Fischers().Informer()
+	case o.APIVersion == v1beta1.SchemeGroupVersion.Version:
+		informer = versions.V1beta1().Flunders().Informer()
+	case resource == "fischers":
+		informer = versions.V1alpha1().Fischers().Informer()
+	default:
+		informer = versions.V1alpha1().Flunders().Informer()
+	}
+
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			o.printEvent("ADDED", kind, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			o.printEvent("MODIFIED", kind, newObj)
+			o.printDiff(oldObj, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			o.printEvent("DELETED", kind, obj)
+		},
+	}); err != nil {
+		return err
+	}
+
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	factory.Shutdown()
+	return nil
+}
+
+func (o *WardlectlOptions) printEvent(event, kind string, obj interface{}) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	fmt.Fprintf(o.StdOut, "%s %s/%s\n", event, kind, accessor.GetName())
+}
+
+func (o *WardlectlOptions) printDiff(oldObj, newObj interface{}) {
+	oldYAML, err := toYAML(oldObj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	newYAML, err := toYAML(newObj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, line := range diffLines(oldYAML, newYAML) {
+		color := green
+		if strings.HasPrefix(line, "-") {
+			color = red
+		}
+		fmt.Fprintf(o.StdOut, "  %s\n", o.colorize(color, line))
+	}
+}
+
+// toYAML marshals obj without its managed fields, which would clutter diffs.
+func toYAML(obj interface{}) (string, error) {
+	runtimeObj, ok := obj.(runtime.Object)
+	if !ok {
+		return "", fmt.Errorf("unexpected object of type %T", obj)
+	}
+	runtimeObj = runtimeObj.DeepCopyObject()
+	accessor, err := meta.Accessor(runtimeObj)
+	if err != nil {
+		return "", err
+	}
+	accessor.SetManagedFields(nil)
+	data, err := yaml.Marshal(runtimeObj)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
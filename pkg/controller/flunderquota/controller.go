@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flunderquota implements the controller which keeps the status of
+// FlunderQuotas up to date.
+package flunderquota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	quota "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	clientset "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+	listers "k8s.io/sample-apiserver/pkg/generated/listers/wardle/v1alpha1"
+	wardlequota "k8s.io/sample-apiserver/pkg/quota"
+)
+
+// Controller copies the limits of FlunderQuotas to their status and calculates
+// the usage in their namespace.
+//
+// The FlunderQuota admission plugin charges created objects to the usage
+// itself. The controller recalculates the usage when a FlunderQuota is created
+// or its limits change, when objects are deleted, and every resync period to
+// release the usage charged for objects which were not created after all.
+type Controller struct {
+	client       clientset.Interface
+	lister       listers.FlunderQuotaLister
+	registry     quota.Registry
+	synced       []cache.InformerSynced
+	queue        workqueue.TypedRateLimitingInterface[string]
+	resyncPeriod time.Duration
+}
+
+// NewController returns a controller which watches the FlunderQuotas and the
+// limited objects in the informers of f. The informers must be started after
+// the controller is created.
+func NewController(client clientset.Interface, f informers.SharedInformerFactory, resyncPeriod time.Duration) (*Controller, error) {
+	quotas := f.Wardle().V1alpha1().FlunderQuotas()
+	c := &Controller{
+		client:   client,
+		lister:   quotas.Lister(),
+		registry: wardlequota.NewRegistry(f),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "flunderquota"},
+		),
+		resyncPeriod: resyncPeriod,
+	}
+
+	registration, err := quotas.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueue,
+		UpdateFunc: func(old, obj interface{}) {
+			// the admission plugin updates the usage, which is not recalculated
+			if fq, ok := obj.(*v1alpha1.FlunderQuota); ok && !quota.Equals(fq.Spec.Hard, fq.Status.Hard) {
+				c.enqueue(obj)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.synced = append(c.synced, registration.HasSynced)
+
+	for _, evaluator := range c.registry.List() {
+		informer, err := f.ForResource(v1alpha1.SchemeGroupVersion.WithResource(evaluator.GroupResource().Resource))
+		if err != nil {
+			return nil, err
+		}
+		registration, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			DeleteFunc: c.enqueueNamespaceOf,
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.synced = append(c.synced, registration.HasSynced)
+	}
+
+	return c, nil
+}
+
+// Run runs the given number of workers until ctx is done.
+func (c *Controller) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	if !cache.WaitForCacheSync(ctx.Done(), c.synced...) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.worker, time.Second)
+	}
+	go wait.UntilWithContext(ctx, c.enqueueAll, c.resyncPeriod)
+
+	<-ctx.Done()
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get the key of %#v: %w", obj, err))
+		return
+	}
+	c.queue.Add(key)
+}
+
+// enqueueNamespaceOf enqueues all FlunderQuotas in the namespace of obj.
+func (c *Controller) enqueueNamespaceOf(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get the key of %#v: %w", obj, err))
+		return
+	}
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	quotas, err := c.lister.FlunderQuotas(namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list flunder quotas in %q: %w", namespace, err))
+		return
+	}
+	for _, fq := range quotas {
+		c.enqueue(fq)
+	}
+}
+
+func (c *Controller) enqueueAll(ctx context.Context) {
+	quotas, err := c.lister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list flunder quotas: %w", err))
+		return
+	}
+	for _, fq := range quotas {
+		c.enqueue(fq)
+	}
+}
+
+func (c *Controller) worker(ctx context.Context) {
+	for c.processNextItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextItem(ctx context.Context) bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.sync(ctx, key); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to sync flunder quota %q: %w", key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+// sync updates the status of the FlunderQuota with the given key.
+func (c *Controller) sync(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	fq, err := c.lister.FlunderQuotas(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	used, err := quota.CalculateUsage(namespace, nil, fq.Spec.Hard, c.registry, nil)
+	if err != nil {
+		return err
+	}
+	if quota.Equals(fq.Status.Hard, fq.Spec.Hard) && quota.Equals(fq.Status.Used, used) {
+		return nil
+	}
+
+	updated := fq.DeepCopy()
+	updated.Status = v1alpha1.FlunderQuotaStatus{
+		Hard: fq.Spec.Hard,
+		Used: used,
+	}
+	_, err = c.client.WardleV1alpha1().FlunderQuotas(namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
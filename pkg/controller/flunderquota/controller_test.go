@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flunderquota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/fake"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+)
+
+func flunderCount(n int64) corev1.ResourceList {
+	return corev1.ResourceList{v1alpha1.ResourceFlunders: *resource.NewQuantity(n, resource.DecimalSI)}
+}
+
+func TestController(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs := fake.NewSimpleClientset(
+		&v1alpha1.FlunderQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "team"},
+			Spec:       v1alpha1.FlunderQuotaSpec{Hard: flunderCount(5)},
+		},
+		&v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team"}},
+		&v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "team"}},
+		&v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "other"}},
+	)
+	factory := informers.NewSharedInformerFactory(cs, 0)
+	controller, err := NewController(cs, factory, time.Hour)
+	require.NoError(t, err)
+	factory.Start(ctx.Done())
+	go controller.Run(ctx, 1)
+
+	expectStatus := func(hard, used int64) {
+		t.Helper()
+		var last v1alpha1.FlunderQuotaStatus
+		err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+			fq, err := cs.WardleV1alpha1().FlunderQuotas("team").Get(ctx, "quota", metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			last = fq.Status
+			return last.Hard.Name(v1alpha1.ResourceFlunders, resource.DecimalSI).Value() == hard &&
+				last.Used.Name(v1alpha1.ResourceFlunders, resource.DecimalSI).Value() == used, nil
+		})
+		assert.NoError(t, err, "expected hard %d and used %d, got %v", hard, used, last)
+	}
+
+	// the usage is calculated for new quotas
+	expectStatus(5, 2)
+
+	// deletions release the usage
+	require.NoError(t, cs.WardleV1alpha1().Flunders("team").Delete(ctx, "a", metav1.DeleteOptions{}))
+	expectStatus(5, 1)
+
+	// changed limits are copied to the status
+	fq, err := cs.WardleV1alpha1().FlunderQuotas("team").Get(ctx, "quota", metav1.GetOptions{})
+	require.NoError(t, err)
+	fq.Spec.Hard = flunderCount(1)
+	_, err = cs.WardleV1alpha1().FlunderQuotas("team").Update(ctx, fq, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	expectStatus(1, 1)
+}
+
+func TestSyncWithoutLimits(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs := fake.NewSimpleClientset(&v1alpha1.FlunderQuota{ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "team"}})
+	factory := informers.NewSharedInformerFactory(cs, 0)
+	controller, err := NewController(cs, factory, time.Hour)
+	require.NoError(t, err)
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	require.NoError(t, controller.sync(ctx, "team/quota"))
+	require.NoError(t, controller.sync(ctx, "team/missing"))
+	for _, action := range cs.Actions() {
+		assert.NotEqual(t, "update", action.GetVerb(), "a quota without limits has nothing to update")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversionwebhook
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// The types in this file mirror the apiextensions.k8s.io/v1 ConversionReview
+// wire format. They are defined here to avoid a dependency on
+// k8s.io/apiextensions-apiserver.
+
+// ConversionReviewAPIVersion is the only supported apiVersion of ConversionReviews.
+const ConversionReviewAPIVersion = "apiextensions.k8s.io/v1"
+
+// ConversionReview describes a conversion request/response.
+type ConversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	// Request describes the attributes for the conversion request.
+	Request *ConversionRequest `json:"request,omitempty"`
+	// Response describes the attributes for the conversion response.
+	Response *ConversionResponse `json:"response,omitempty"`
+}
+
+// ConversionRequest describes the conversion request parameters.
+type ConversionRequest struct {
+	// UID is an identifier for the individual request/response.
+	UID types.UID `json:"uid"`
+	// DesiredAPIVersion is the version to convert given objects to, e.g. "wardle.example.com/v1beta1".
+	DesiredAPIVersion string `json:"desiredAPIVersion"`
+	// Objects is the list of objects to convert.
+	Objects []runtime.RawExtension `json:"objects"`
+}
+
+// ConversionResponse describes a conversion response.
+type ConversionResponse struct {
+	// UID is copied from the corresponding request.
+	UID types.UID `json:"uid"`
+	// ConvertedObjects is the list of converted objects, in the order of the request.
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	// Result contains the result of the conversion, with details if it failed.
+	Result metav1.Status `json:"result"`
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversionwebhook serves the wardle version conversions as a
+// CustomResourceDefinition conversion webhook, for clusters which serve the
+// wardle types as custom resources.
+package conversionwebhook
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+)
+
+// Path is the path the conversion webhook is served at.
+const Path = "/convert"
+
+// maxRequestBytes limits the size of ConversionReviews, like the kube-apiserver
+// limits the size of requests.
+const maxRequestBytes = 3 * 1024 * 1024
+
+type handler struct {
+	scheme     *runtime.Scheme
+	serializer runtime.Serializer
+}
+
+// NewHandler returns a handler for ConversionReviews which converts wardle
+// objects with the conversions registered in the given scheme.
+func NewHandler(scheme *runtime.Scheme) http.Handler {
+	return &handler{
+		scheme:     scheme,
+		serializer: json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{}),
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	// read one byte more than allowed to tell a request of the maximum size
+	// from a larger one
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBytes+1))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxRequestBytes {
+		http.Error(w, fmt.Sprintf("request is larger than %d bytes", maxRequestBytes), http.StatusRequestEntityTooLarge)
+		return
+	}
+	review := ConversionReview{}
+	if err := utiljson.Unmarshal(body, &review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode ConversionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.APIVersion != ConversionReviewAPIVersion || review.Kind != "ConversionReview" {
+		http.Error(w, fmt.Sprintf("expected a %s ConversionReview, got %s %s", ConversionReviewAPIVersion, review.APIVersion, review.Kind), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "ConversionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	response, err := utiljson.Marshal(ConversionReview{
+		TypeMeta: review.TypeMeta,
+		Response: h.review(review.Request),
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode ConversionReview: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(response); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to write ConversionReview response: %w", err))
+	}
+}
+
+// review converts all objects of the request. The conversion fails as a whole
+// if any object cannot be converted.
+func (h *handler) review(request *ConversionRequest) *ConversionResponse {
+	response := &ConversionResponse{UID: request.UID}
+
+	desired, err := schema.ParseGroupVersion(request.DesiredAPIVersion)
+	if err != nil {
+		response.Result = failure(err)
+		return response
+	}
+	for i := range request.Objects {
+		converted, err := h.convert(request.Objects[i].Raw, desired)
+		if err != nil {
+			response.Result = failure(fmt.Errorf("failed to convert object %d: %w", i, err))
+			return response
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return response
+}
+
+// convert converts a serialized wardle object to the desired version through
+// the internal version, just like the wardle server does.
+func (h *handler) convert(data []byte, desired schema.GroupVersion) ([]byte, error) {
+	if desired.Group != wardle.GroupName {
+		return nil, fmt.Errorf("cannot convert to %v, only %s is supported", desired, wardle.GroupName)
+	}
+
+	obj, gvk, err := h.serializer.Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if gvk.Group != wardle.GroupName {
+		return nil, fmt.Errorf("cannot convert %v, only %s is supported", gvk, wardle.GroupName)
+	}
+	if gvk.GroupVersion() == desired {
+		return data, nil
+	}
+
+	internal, err := h.scheme.ConvertToVersion(obj, wardle.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+	out, err := h.scheme.ConvertToVersion(internal, desired)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.Encode(h.serializer, out)
+}
+
+func failure(err error) metav1.Status {
+	return metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: err.Error(),
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversionwebhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/install"
+)
+
+func TestConversionReview(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+
+	testCases := []struct {
+		desc            string
+		desired         string
+		objects         []string
+		expectedStatus  string
+		expectedObjects []string
+	}{
+		{
+			desc:    "v1alpha1 to v1beta1",
+			desired: "wardle.example.com/v1beta1",
+			objects: []string{
+				`{"apiVersion":"wardle.example.com/v1alpha1","kind":"Flunder","metadata":{"name":"foo"},"spec":{"reference":"bar","referenceType":"Fischer"}}`,
+			},
+			expectedStatus: metav1.StatusSuccess,
+			expectedObjects: []string{
+				`{"kind":"Flunder","apiVersion":"wardle.example.com/v1beta1","metadata":{"name":"foo","creationTimestamp":null},"spec":{"fischerReference":"bar","referenceType":"Fischer"},"status":{}}`,
+			},
+		},
+		{
+			desc:    "v1beta1 to v1alpha1 with unrepresentable reference",
+			desired: "wardle.example.com/v1alpha1",
+			objects: []string{
+				`{"apiVersion":"wardle.example.com/v1beta1","kind":"Flunder","metadata":{"name":"foo"},"spec":{"flunderReference":"bar","fischerReference":"baz","referenceType":"Flunder"}}`,
+			},
+			expectedStatus: metav1.StatusSuccess,
+			expectedObjects: []string{
+				`{"kind":"Flunder","apiVersion":"wardle.example.com/v1alpha1","metadata":{"name":"foo","creationTimestamp":null,"annotations":{"wardle.example.com/fischer-reference":"baz"}},"spec":{"reference":"bar","referenceType":"Flunder"},"status":{}}`,
+			},
+		},
+		{
+			desc:    "same version",
+			desired: "wardle.example.com/v1alpha1",
+			objects: []string{
+				`{"apiVersion":"wardle.example.com/v1alpha1","kind":"Flunder","metadata":{"name":"foo"}}`,
+			},
+			expectedStatus: metav1.StatusSuccess,
+			expectedObjects: []string{
+				`{"apiVersion":"wardle.example.com/v1alpha1","kind":"Flunder","metadata":{"name":"foo"}}`,
+			},
+		},
+		{
+			desc:    "other group",
+			desired: "apps/v1",
+			objects: []string{
+				`{"apiVersion":"wardle.example.com/v1alpha1","kind":"Flunder","metadata":{"name":"foo"}}`,
+			},
+			expectedStatus: metav1.StatusFailure,
+		},
+		{
+			desc:    "unknown kind",
+			desired: "wardle.example.com/v1beta1",
+			objects: []string{
+				`{"apiVersion":"wardle.example.com/v1alpha1","kind":"Unknown","metadata":{"name":"foo"}}`,
+			},
+			expectedStatus: metav1.StatusFailure,
+		},
+	}
+
+	server := httptest.NewServer(NewHandler(scheme))
+	defer server.Close()
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			request := &ConversionRequest{UID: "uid", DesiredAPIVersion: tc.desired}
+			for _, obj := range tc.objects {
+				request.Objects = append(request.Objects, runtime.RawExtension{Raw: []byte(obj)})
+			}
+			body, err := json.Marshal(ConversionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: ConversionReviewAPIVersion, Kind: "ConversionReview"},
+				Request:  request,
+			})
+			require.NoError(t, err)
+
+			resp, err := http.Post(server.URL+Path, "application/json", bytes.NewReader(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			review := ConversionReview{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&review))
+			require.NotNil(t, review.Response)
+			assert.Equal(t, ConversionReviewAPIVersion, review.APIVersion)
+			assert.Equal(t, request.UID, review.Response.UID)
+			assert.Equal(t, tc.expectedStatus, review.Response.Result.Status, review.Response.Result.Message)
+
+			var converted []string
+			for _, obj := range review.Response.ConvertedObjects {
+				converted = append(converted, string(bytes.TrimSpace(obj.Raw)))
+			}
+			if tc.expectedStatus == metav1.StatusSuccess {
+				assert.Equal(t, tc.expectedObjects, converted)
+			}
+		})
+	}
+}
+
+func TestConversionReviewBadRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	server := httptest.NewServer(NewHandler(scheme))
+	defer server.Close()
+
+	for desc, body := range map[string]string{
+		"not json":      `{`,
+		"wrong version": `{"apiVersion":"apiextensions.k8s.io/v1beta1","kind":"ConversionReview","request":{}}`,
+		"no request":    `{"apiVersion":"apiextensions.k8s.io/v1","kind":"ConversionReview"}`,
+	} {
+		t.Run(desc, func(t *testing.T) {
+			resp, err := http.Post(server.URL+Path, "application/json", bytes.NewReader([]byte(body)))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestConversionReviewTooLarge(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	server := httptest.NewServer(NewHandler(scheme))
+	defer server.Close()
+
+	body := bytes.Repeat([]byte(" "), maxRequestBytes+1)
+	resp, err := http.Post(server.URL+Path, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
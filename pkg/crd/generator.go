@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crd generates CustomResourceDefinitions which are equivalent to the
+// wardle API, for clusters which serve the wardle types as custom resources.
+package crd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/sample-apiserver/pkg/conversionwebhook"
+)
+
+const (
+	definitionPrefix = "#/definitions/"
+	objectMetaName   = "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"
+)
+
+// Resource describes a resource to generate a CustomResourceDefinition for.
+type Resource struct {
+	Group      string
+	Kind       string
+	Plural     string
+	Singular   string
+	Namespaced bool
+	// Versions are the served versions of the resource.
+	Versions []Version
+	// StorageVersion is the version the resource is stored in. It must be one
+	// of Versions.
+	StorageVersion string
+	// StatusSubresource is whether the resource serves its status as a
+	// subresource, like the wardle server does for resources with
+	// <resource>/status storage. Otherwise updates of the resource write the
+	// status.
+	StatusSubresource bool
+}
+
+// Version describes a served version of a resource.
+type Version struct {
+	Name string
+	// PrinterColumns are shown by kubectl get in addition to the name. Their
+	// JSONPaths must point to fields of the version.
+	PrinterColumns []CustomResourceColumnDefinition
+}
+
+// Generator generates CustomResourceDefinitions from OpenAPI definitions.
+type Generator struct {
+	definitions map[string]common.OpenAPIDefinition
+	scheme      *runtime.Scheme
+
+	// Webhook is the client config of the conversion webhook, which is required
+	// for resources with several versions. The path of a service defaults to
+	// the path served by the conversionwebhook package.
+	Webhook *WebhookClientConfig
+}
+
+// NewGenerator returns a Generator for the types of the given scheme, whose
+// OpenAPI definitions are returned by getDefinitions.
+func NewGenerator(getDefinitions common.GetOpenAPIDefinitions, scheme *runtime.Scheme) *Generator {
+	return &Generator{
+		definitions: getDefinitions(func(name string) spec.Ref {
+			return spec.MustCreateRef(definitionPrefix + name)
+		}),
+		scheme: scheme,
+	}
+}
+
+// Generate returns the CustomResourceDefinition of the given resource.
+func (g *Generator) Generate(r Resource) (*CustomResourceDefinition, error) {
+	if len(r.Versions) == 0 {
+		return nil, fmt.Errorf("%s has no versions", r.Kind)
+	}
+	if !slices.ContainsFunc(r.Versions, func(v Version) bool { return v.Name == r.StorageVersion }) {
+		return nil, fmt.Errorf("storage version %q of %s is not served", r.StorageVersion, r.Kind)
+	}
+
+	crd := &CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: APIVersion,
+			Kind:       "CustomResourceDefinition",
+		},
+		ObjectMeta: ObjectMeta{
+			Name: r.Plural + "." + r.Group,
+		},
+		Spec: CustomResourceDefinitionSpec{
+			Group: r.Group,
+			Names: CustomResourceDefinitionNames{
+				Plural:   r.Plural,
+				Singular: r.Singular,
+				Kind:     r.Kind,
+				ListKind: r.Kind + "List",
+			},
+			Scope:      ClusterScoped,
+			Conversion: &CustomResourceConversion{Strategy: NoneConverter},
+		},
+	}
+	if r.Namespaced {
+		crd.Spec.Scope = NamespaceScoped
+	}
+
+	for _, version := range r.Versions {
+		s, err := g.Schema(schema.GroupVersionKind{Group: r.Group, Version: version.Name, Kind: r.Kind})
+		if err != nil {
+			return nil, err
+		}
+		for _, column := range version.PrinterColumns {
+			if !hasField(s, column.JSONPath) {
+				return nil, fmt.Errorf("printer column %q of %s %s refers to unknown field %s", column.Name, r.Kind, version.Name, column.JSONPath)
+			}
+		}
+		v := CustomResourceDefinitionVersion{
+			Name:                     version.Name,
+			Served:                   true,
+			Storage:                  version.Name == r.StorageVersion,
+			Schema:                   &CustomResourceValidation{OpenAPIV3Schema: s},
+			AdditionalPrinterColumns: version.PrinterColumns,
+		}
+		if r.StatusSubresource {
+			if _, found := s.Properties["status"]; !found {
+				return nil, fmt.Errorf("%s %s has no status to serve as a subresource", r.Kind, version.Name)
+			}
+			v.Subresources = &CustomResourceSubresources{Status: &CustomResourceSubresourceStatus{}}
+		}
+		crd.Spec.Versions = append(crd.Spec.Versions, v)
+	}
+
+	if len(r.Versions) > 1 {
+		// Without a webhook, the versions would only differ in their apiVersion.
+		if g.Webhook == nil {
+			return nil, fmt.Errorf("%s has several versions and requires a conversion webhook", r.Kind)
+		}
+		clientConfig := *g.Webhook
+		if clientConfig.Service != nil && clientConfig.Service.Path == nil {
+			service := *clientConfig.Service
+			path := conversionwebhook.Path
+			service.Path = &path
+			clientConfig.Service = &service
+		}
+		crd.Spec.Conversion = &CustomResourceConversion{
+			Strategy: WebhookConverter,
+			Webhook: &WebhookConversion{
+				ClientConfig:             &clientConfig,
+				ConversionReviewVersions: []string{"v1"},
+			},
+		}
+	}
+
+	return crd, nil
+}
+
+// Schema returns the structural schema of the given kind. References to other
+// definitions are inlined, and metadata is left to the server.
+func (g *Generator) Schema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	t, found := g.scheme.AllKnownTypes()[gvk]
+	if !found {
+		return nil, fmt.Errorf("%v is not registered in the scheme", gvk)
+	}
+	name := t.PkgPath() + "." + t.Name()
+	def, found := g.definitions[name]
+	if !found {
+		return nil, fmt.Errorf("no OpenAPI definition for %s", name)
+	}
+
+	s, err := g.inline(def.Schema, []string{name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the schema of %v: %w", gvk, err)
+	}
+	return &s, nil
+}
+
+// inline returns a copy of s with all references replaced by the referenced
+// definitions. path holds the definitions which are being inlined, to detect
+// recursive types, which cannot be expressed in a structural schema.
+func (g *Generator) inline(s spec.Schema, path []string) (spec.Schema, error) {
+	// openapi-gen wraps references with a default in allOf
+	if len(s.AllOf) == 1 && len(s.AllOf[0].Ref.String()) != 0 {
+		s.Ref = s.AllOf[0].Ref
+		s.AllOf = nil
+	}
+
+	if ref := s.Ref.String(); len(ref) != 0 {
+		name := strings.TrimPrefix(ref, definitionPrefix)
+		// the server validates and prunes metadata itself
+		if name == objectMetaName {
+			return spec.Schema{SchemaProps: spec.SchemaProps{
+				Description: s.Description,
+				Type:        []string{"object"},
+			}}, nil
+		}
+		if slices.Contains(path, name) {
+			return spec.Schema{}, fmt.Errorf("recursive reference to %s", name)
+		}
+		def, found := g.definitions[name]
+		if !found {
+			return spec.Schema{}, fmt.Errorf("no OpenAPI definition for %s", name)
+		}
+		resolved, err := g.inline(def.Schema, append(slices.Clone(path), name))
+		if err != nil {
+			return spec.Schema{}, err
+		}
+		if len(s.Description) != 0 {
+			resolved.Description = s.Description
+		}
+		if s.Default != nil {
+			resolved.Default = s.Default
+		}
+		return resolved, nil
+	}
+
+	// types like resource.Quantity are either a string or a number, which a
+	// structural schema expresses as int-or-string
+	if len(s.Type) == 0 && isStringOrNumber(s.OneOf) {
+		s.OneOf = nil
+		s.AnyOf = []spec.Schema{
+			{SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+			{SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+		}
+		s.Extensions = maps.Clone(s.Extensions)
+		s.AddExtension("x-kubernetes-int-or-string", true)
+	}
+
+	if intOrString, _ := s.Extensions.GetBool("x-kubernetes-int-or-string"); len(s.Type) == 0 && !intOrString {
+		return spec.Schema{}, fmt.Errorf("schema of %s has no type", path[len(path)-1])
+	}
+
+	if s.Properties != nil {
+		properties := make(map[string]spec.Schema, len(s.Properties))
+		for name, property := range s.Properties {
+			inlined, err := g.inline(property, path)
+			if err != nil {
+				return spec.Schema{}, err
+			}
+			properties[name] = inlined
+		}
+		s.Properties = properties
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		inlined, err := g.inline(*s.Items.Schema, path)
+		if err != nil {
+			return spec.Schema{}, err
+		}
+		s.Items = &spec.SchemaOrArray{Schema: &inlined}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		inlined, err := g.inline(*s.AdditionalProperties.Schema, path)
+		if err != nil {
+			return spec.Schema{}, err
+		}
+		s.AdditionalProperties = &spec.SchemaOrBool{Allows: true, Schema: &inlined}
+	}
+
+	return s, nil
+}
+
+// isStringOrNumber returns whether the oneOf schemas are a string and a number.
+func isStringOrNumber(oneOf []spec.Schema) bool {
+	types := make([]string, 0, len(oneOf))
+	for _, s := range oneOf {
+		types = append(types, s.Type...)
+	}
+	slices.Sort(types)
+	return slices.Equal(types, []string{"integer", "string"}) || slices.Equal(types, []string{"number", "string"})
+}
+
+// hasField returns whether the simple JSONPath, like .spec.reference, points to
+// a field of the schema. Metadata fields are not checked.
+func hasField(s *spec.Schema, jsonPath string) bool {
+	fields := strings.Split(strings.TrimPrefix(jsonPath, "."), ".")
+	if fields[0] == "metadata" {
+		return true
+	}
+	for _, field := range fields {
+		property, found := s.Properties[field]
+		if !found {
+			return false
+		}
+		s = &property
+	}
+	return true
+}
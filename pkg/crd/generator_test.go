@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/install"
+	sampleopenapi "k8s.io/sample-apiserver/pkg/generated/openapi"
+)
+
+func newTestGenerator() *Generator {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	return NewGenerator(sampleopenapi.GetOpenAPIDefinitions, scheme)
+}
+
+var flunders = Resource{
+	Group:      "wardle.example.com",
+	Kind:       "Flunder",
+	Plural:     "flunders",
+	Singular:   "flunder",
+	Namespaced: true,
+	Versions: []Version{
+		{Name: "v1alpha1", PrinterColumns: []CustomResourceColumnDefinition{{Name: "Reference", Type: "string", JSONPath: ".spec.reference"}}},
+		{Name: "v1beta1"},
+	},
+	StorageVersion: "v1alpha1",
+}
+
+func TestGenerate(t *testing.T) {
+	g := newTestGenerator()
+	g.Webhook = &WebhookClientConfig{Service: &ServiceReference{Namespace: "wardle", Name: "api"}}
+
+	crd, err := g.Generate(flunders)
+	require.NoError(t, err)
+
+	assert.Equal(t, "flunders.wardle.example.com", crd.Name)
+	assert.Equal(t, NamespaceScoped, crd.Spec.Scope)
+	assert.Equal(t, "FlunderList", crd.Spec.Names.ListKind)
+	require.Len(t, crd.Spec.Versions, 2)
+	assert.True(t, crd.Spec.Versions[0].Storage)
+	assert.False(t, crd.Spec.Versions[1].Storage)
+	assert.Equal(t, flunders.Versions[0].PrinterColumns, crd.Spec.Versions[0].AdditionalPrinterColumns)
+	assert.Empty(t, crd.Spec.Versions[1].AdditionalPrinterColumns)
+
+	require.NotNil(t, crd.Spec.Conversion.Webhook)
+	assert.Equal(t, WebhookConverter, crd.Spec.Conversion.Strategy)
+	assert.Equal(t, []string{"v1"}, crd.Spec.Conversion.Webhook.ConversionReviewVersions)
+	require.NotNil(t, crd.Spec.Conversion.Webhook.ClientConfig.Service.Path)
+	assert.Equal(t, "/convert", *crd.Spec.Conversion.Webhook.ClientConfig.Service.Path)
+	assert.Nil(t, g.Webhook.Service.Path, "the webhook of the generator must not be mutated")
+
+	for _, v := range crd.Spec.Versions {
+		s := v.Schema.OpenAPIV3Schema
+		assertStructural(t, v.Name, *s)
+		assert.Nil(t, v.Subresources, "flunders serve no status subresource")
+		assert.Equal(t, spec.StringOrArray{"object"}, s.Properties["metadata"].Type)
+		assert.Nil(t, s.Properties["metadata"].Default)
+	}
+	v1beta1Spec := crd.Spec.Versions[1].Schema.OpenAPIV3Schema.Properties["spec"]
+	assert.Equal(t, map[string]interface{}{}, v1beta1Spec.Default)
+	assert.Contains(t, v1beta1Spec.Properties, "fischerReference")
+	assert.Contains(t, v1beta1Spec.Properties, "flunderReference")
+}
+
+func TestGenerateSingleVersion(t *testing.T) {
+	crd, err := newTestGenerator().Generate(Resource{
+		Group:          "wardle.example.com",
+		Kind:           "Fischer",
+		Plural:         "fischers",
+		Singular:       "fischer",
+		Versions:       []Version{{Name: "v1alpha1"}},
+		StorageVersion: "v1alpha1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, ClusterScoped, crd.Spec.Scope)
+	assert.Equal(t, NoneConverter, crd.Spec.Conversion.Strategy)
+	assert.Nil(t, crd.Spec.Versions[0].Subresources)
+	s := crd.Spec.Versions[0].Schema.OpenAPIV3Schema
+	assertStructural(t, "v1alpha1", *s)
+	assert.Equal(t, spec.StringOrArray{"string"}, s.Properties["disallowedFlunders"].Items.Schema.Type)
+}
+
+func TestGenerateQuantity(t *testing.T) {
+	crd, err := newTestGenerator().Generate(Resource{
+		Group:             "wardle.example.com",
+		Kind:              "FlunderQuota",
+		Plural:            "flunderquotas",
+		Singular:          "flunderquota",
+		Namespaced:        true,
+		Versions:          []Version{{Name: "v1alpha1"}},
+		StorageVersion:    "v1alpha1",
+		StatusSubresource: true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &CustomResourceSubresources{Status: &CustomResourceSubresourceStatus{}}, crd.Spec.Versions[0].Subresources)
+	s := crd.Spec.Versions[0].Schema.OpenAPIV3Schema
+	assertStructural(t, "v1alpha1", *s)
+	hard := s.Properties["spec"].Properties["hard"].AdditionalProperties.Schema
+	intOrString, _ := hard.Extensions.GetBool("x-kubernetes-int-or-string")
+	assert.True(t, intOrString, "quantities must be int-or-string")
+	assert.Empty(t, hard.OneOf)
+	require.Len(t, hard.AnyOf, 2)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		modify   func(*Resource)
+		expected string
+	}{
+		{
+			desc:     "no webhook",
+			modify:   func(*Resource) {},
+			expected: "requires a conversion webhook",
+		},
+		{
+			desc:     "storage version not served",
+			modify:   func(r *Resource) { r.StorageVersion = "v1" },
+			expected: "is not served",
+		},
+		{
+			desc:     "unknown version",
+			modify:   func(r *Resource) { r.Versions = []Version{{Name: "v1"}}; r.StorageVersion = "v1" },
+			expected: "is not registered in the scheme",
+		},
+		{
+			desc: "printer column of unknown field",
+			modify: func(r *Resource) {
+				r.Versions[1].PrinterColumns = []CustomResourceColumnDefinition{{Name: "Reference", Type: "string", JSONPath: ".spec.reference"}}
+			},
+			expected: "refers to unknown field .spec.reference",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := flunders
+			r.Versions = append([]Version(nil), r.Versions...)
+			tc.modify(&r)
+			_, err := newTestGenerator().Generate(r)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+// assertStructural checks that a schema has no references and that every node
+// has a type.
+func assertStructural(t *testing.T, path string, s spec.Schema) {
+	t.Helper()
+	assert.Empty(t, s.Ref.String(), path)
+	assert.Empty(t, s.AllOf, path)
+	assert.Empty(t, s.OneOf, path)
+	if intOrString, _ := s.Extensions.GetBool("x-kubernetes-int-or-string"); !intOrString {
+		assert.NotEmpty(t, s.Type, path)
+	}
+	for name, property := range s.Properties {
+		assertStructural(t, path+"."+name, property)
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		assertStructural(t, path+"[]", *s.Items.Schema)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		assertStructural(t, path+"{}", *s.AdditionalProperties.Schema)
+	}
+}
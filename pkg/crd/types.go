@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// The types in this file mirror the subset of the apiextensions.k8s.io/v1
+// CustomResourceDefinition API which is needed to serve the wardle types as
+// custom resources. They are defined here to avoid a dependency on
+// k8s.io/apiextensions-apiserver.
+
+// APIVersion is the apiVersion of the generated CustomResourceDefinitions.
+const APIVersion = "apiextensions.k8s.io/v1"
+
+// CustomResourceDefinition represents a resource that should be exposed on the API server.
+type CustomResourceDefinition struct {
+	metav1.TypeMeta `json:",inline"`
+	ObjectMeta      `json:"metadata,omitempty"`
+
+	Spec CustomResourceDefinitionSpec `json:"spec"`
+}
+
+// ObjectMeta is the subset of metav1.ObjectMeta which is set by the generator.
+// Unlike metav1.ObjectMeta, it serializes without a null creationTimestamp.
+type ObjectMeta struct {
+	Name        string            `json:"name,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// CustomResourceDefinitionSpec describes how a user wants their resource to appear.
+type CustomResourceDefinitionSpec struct {
+	Group      string                            `json:"group"`
+	Names      CustomResourceDefinitionNames     `json:"names"`
+	Scope      ResourceScope                     `json:"scope"`
+	Versions   []CustomResourceDefinitionVersion `json:"versions"`
+	Conversion *CustomResourceConversion         `json:"conversion,omitempty"`
+}
+
+// CustomResourceDefinitionNames indicates the names to serve this CustomResourceDefinition.
+type CustomResourceDefinitionNames struct {
+	Plural   string `json:"plural"`
+	Singular string `json:"singular,omitempty"`
+	Kind     string `json:"kind"`
+	ListKind string `json:"listKind,omitempty"`
+}
+
+// ResourceScope is an enum defining the different scopes available to a custom resource.
+type ResourceScope string
+
+const (
+	ClusterScoped   ResourceScope = "Cluster"
+	NamespaceScoped ResourceScope = "Namespaced"
+)
+
+// CustomResourceDefinitionVersion describes a version for a CustomResourceDefinition.
+type CustomResourceDefinitionVersion struct {
+	Name                     string                           `json:"name"`
+	Served                   bool                             `json:"served"`
+	Storage                  bool                             `json:"storage"`
+	Schema                   *CustomResourceValidation        `json:"schema,omitempty"`
+	Subresources             *CustomResourceSubresources      `json:"subresources,omitempty"`
+	AdditionalPrinterColumns []CustomResourceColumnDefinition `json:"additionalPrinterColumns,omitempty"`
+}
+
+// CustomResourceColumnDefinition specifies a column for server side printing.
+type CustomResourceColumnDefinition struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Format      string `json:"format,omitempty"`
+	Description string `json:"description,omitempty"`
+	Priority    int32  `json:"priority,omitempty"`
+	JSONPath    string `json:"jsonPath"`
+}
+
+// CustomResourceSubresources defines the status and scale subresources for CustomResources.
+type CustomResourceSubresources struct {
+	Status *CustomResourceSubresourceStatus `json:"status,omitempty"`
+}
+
+// CustomResourceSubresourceStatus defines how to serve the status subresource
+// for CustomResources. It has no fields.
+type CustomResourceSubresourceStatus struct{}
+
+// CustomResourceValidation is a list of validation methods for CustomResources.
+type CustomResourceValidation struct {
+	OpenAPIV3Schema *spec.Schema `json:"openAPIV3Schema,omitempty"`
+}
+
+// ConversionStrategyType describes different conversion types.
+type ConversionStrategyType string
+
+const (
+	NoneConverter    ConversionStrategyType = "None"
+	WebhookConverter ConversionStrategyType = "Webhook"
+)
+
+// CustomResourceConversion describes how to convert different versions of a CR.
+type CustomResourceConversion struct {
+	Strategy ConversionStrategyType `json:"strategy"`
+	Webhook  *WebhookConversion     `json:"webhook,omitempty"`
+}
+
+// WebhookConversion describes how to call a conversion webhook.
+type WebhookConversion struct {
+	ClientConfig             *WebhookClientConfig `json:"clientConfig,omitempty"`
+	ConversionReviewVersions []string             `json:"conversionReviewVersions"`
+}
+
+// WebhookClientConfig contains the information to make a TLS connection with the webhook.
+type WebhookClientConfig struct {
+	URL      *string           `json:"url,omitempty"`
+	Service  *ServiceReference `json:"service,omitempty"`
+	CABundle []byte            `json:"caBundle,omitempty"`
+}
+
+// ServiceReference holds a reference to Service.legacy.k8s.io.
+type ServiceReference struct {
+	Namespace string  `json:"namespace"`
+	Name      string  `json:"name"`
+	Path      *string `json:"path,omitempty"`
+	Port      *int32  `json:"port,omitempty"`
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package features defines the wardle features which gate fields of the
+// wardle types. The registry strategies drop the fields of disabled features
+// on write.
+package features
+
+import (
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// FlunderOwnershipPolicy enables spec.ownershipPolicy of Flunders.
+	FlunderOwnershipPolicy featuregate.Feature = "FlunderOwnershipPolicy"
+
+	// FischerNearMissDistance enables nearMissDistance of Fischers.
+	FischerNearMissDistance featuregate.Feature = "FischerNearMissDistance"
+
+	// FischerFlunderDefaults enables flunderDefaults of Fischers.
+	FischerFlunderDefaults featuregate.Feature = "FischerFlunderDefaults"
+)
+
+// DefaultVersionedFeatureGates are the versioned specifications of the
+// features gating fields. Together with the emulated wardle version they
+// determine whether a feature is enabled.
+var DefaultVersionedFeatureGates = map[featuregate.Feature]featuregate.VersionedSpecs{
+	FlunderOwnershipPolicy: {
+		{Version: version.MustParse("1.2"), Default: true, PreRelease: featuregate.Beta},
+	},
+	FischerNearMissDistance: {
+		{Version: version.MustParse("1.2"), Default: true, PreRelease: featuregate.Beta},
+		{Version: version.MustParse("1.1"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	FischerFlunderDefaults: {
+		{Version: version.MustParse("1.2"), Default: true, PreRelease: featuregate.Beta},
+	},
+}
@@ -38,12 +38,28 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &wardlev1alpha1.FischerApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Flunder"):
 		return &wardlev1alpha1.FlunderApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FlunderDefaults"):
+		return &wardlev1alpha1.FlunderDefaultsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FlunderPolicy"):
+		return &wardlev1alpha1.FlunderPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FlunderPolicySpec"):
+		return &wardlev1alpha1.FlunderPolicySpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FlunderQuota"):
+		return &wardlev1alpha1.FlunderQuotaApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FlunderQuotaSpec"):
+		return &wardlev1alpha1.FlunderQuotaSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FlunderQuotaStatus"):
+		return &wardlev1alpha1.FlunderQuotaStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("FlunderSpec"):
 		return &wardlev1alpha1.FlunderSpecApplyConfiguration{}
 
 		// Group=wardle.example.com, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithKind("Fischer"):
+		return &wardlev1beta1.FischerApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Flunder"):
 		return &wardlev1beta1.FlunderApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FlunderDefaults"):
+		return &wardlev1beta1.FlunderDefaultsApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlunderSpec"):
 		return &wardlev1beta1.FlunderSpecApplyConfiguration{}
 
@@ -29,7 +29,9 @@ import (
 type FischerApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	DisallowedFlunders               []string `json:"disallowedFlunders,omitempty"`
+	DisallowedFlunders               []string                            `json:"disallowedFlunders,omitempty"`
+	FlunderDefaults                  []FlunderDefaultsApplyConfiguration `json:"flunderDefaults,omitempty"`
+	NearMissDistance                 *int32                              `json:"nearMissDistance,omitempty"`
 }
 
 // Fischer constructs a declarative configuration of the Fischer type for use with
@@ -210,6 +212,27 @@ func (b *FischerApplyConfiguration) WithDisallowedFlunders(values ...string) *Fi
 	return b
 }
 
+// WithFlunderDefaults adds the given value to the FlunderDefaults field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FlunderDefaults field.
+func (b *FischerApplyConfiguration) WithFlunderDefaults(values ...*FlunderDefaultsApplyConfiguration) *FischerApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFlunderDefaults")
+		}
+		b.FlunderDefaults = append(b.FlunderDefaults, *values[i])
+	}
+	return b
+}
+
+// WithNearMissDistance sets the NearMissDistance field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NearMissDistance field is set to the value of the last call.
+func (b *FischerApplyConfiguration) WithNearMissDistance(value int32) *FischerApplyConfiguration {
+	b.NearMissDistance = &value
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *FischerApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// FlunderPolicySpecApplyConfiguration represents a declarative configuration of the FlunderPolicySpec type for use
+// with apply.
+type FlunderPolicySpecApplyConfiguration struct {
+	DisallowedFlunders []string `json:"disallowedFlunders,omitempty"`
+	AllowedFlunders    []string `json:"allowedFlunders,omitempty"`
+}
+
+// FlunderPolicySpecApplyConfiguration constructs a declarative configuration of the FlunderPolicySpec type for use with
+// apply.
+func FlunderPolicySpec() *FlunderPolicySpecApplyConfiguration {
+	return &FlunderPolicySpecApplyConfiguration{}
+}
+
+// WithDisallowedFlunders adds the given value to the DisallowedFlunders field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DisallowedFlunders field.
+func (b *FlunderPolicySpecApplyConfiguration) WithDisallowedFlunders(values ...string) *FlunderPolicySpecApplyConfiguration {
+	for i := range values {
+		b.DisallowedFlunders = append(b.DisallowedFlunders, values[i])
+	}
+	return b
+}
+
+// WithAllowedFlunders adds the given value to the AllowedFlunders field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AllowedFlunders field.
+func (b *FlunderPolicySpecApplyConfiguration) WithAllowedFlunders(values ...string) *FlunderPolicySpecApplyConfiguration {
+	for i := range values {
+		b.AllowedFlunders = append(b.AllowedFlunders, values[i])
+	}
+	return b
+}
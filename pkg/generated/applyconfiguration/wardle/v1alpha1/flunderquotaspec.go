@@ -0,0 +1,43 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// FlunderQuotaSpecApplyConfiguration represents a declarative configuration of the FlunderQuotaSpec type for use
+// with apply.
+type FlunderQuotaSpecApplyConfiguration struct {
+	Hard *v1.ResourceList `json:"hard,omitempty"`
+}
+
+// FlunderQuotaSpecApplyConfiguration constructs a declarative configuration of the FlunderQuotaSpec type for use with
+// apply.
+func FlunderQuotaSpec() *FlunderQuotaSpecApplyConfiguration {
+	return &FlunderQuotaSpecApplyConfiguration{}
+}
+
+// WithHard sets the Hard field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hard field is set to the value of the last call.
+func (b *FlunderQuotaSpecApplyConfiguration) WithHard(value v1.ResourceList) *FlunderQuotaSpecApplyConfiguration {
+	b.Hard = &value
+	return b
+}
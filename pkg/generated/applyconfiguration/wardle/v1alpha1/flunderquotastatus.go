@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// FlunderQuotaStatusApplyConfiguration represents a declarative configuration of the FlunderQuotaStatus type for use
+// with apply.
+type FlunderQuotaStatusApplyConfiguration struct {
+	Hard *v1.ResourceList `json:"hard,omitempty"`
+	Used *v1.ResourceList `json:"used,omitempty"`
+}
+
+// FlunderQuotaStatusApplyConfiguration constructs a declarative configuration of the FlunderQuotaStatus type for use with
+// apply.
+func FlunderQuotaStatus() *FlunderQuotaStatusApplyConfiguration {
+	return &FlunderQuotaStatusApplyConfiguration{}
+}
+
+// WithHard sets the Hard field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hard field is set to the value of the last call.
+func (b *FlunderQuotaStatusApplyConfiguration) WithHard(value v1.ResourceList) *FlunderQuotaStatusApplyConfiguration {
+	b.Hard = &value
+	return b
+}
+
+// WithUsed sets the Used field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Used field is set to the value of the last call.
+func (b *FlunderQuotaStatusApplyConfiguration) WithUsed(value v1.ResourceList) *FlunderQuotaStatusApplyConfiguration {
+	b.Used = &value
+	return b
+}
@@ -25,8 +25,9 @@ import (
 // FlunderSpecApplyConfiguration represents a declarative configuration of the FlunderSpec type for use
 // with apply.
 type FlunderSpecApplyConfiguration struct {
-	Reference     *string                       `json:"reference,omitempty"`
-	ReferenceType *wardlev1alpha1.ReferenceType `json:"referenceType,omitempty"`
+	Reference       *string                         `json:"reference,omitempty"`
+	ReferenceType   *wardlev1alpha1.ReferenceType   `json:"referenceType,omitempty"`
+	OwnershipPolicy *wardlev1alpha1.OwnershipPolicy `json:"ownershipPolicy,omitempty"`
 }
 
 // FlunderSpecApplyConfiguration constructs a declarative configuration of the FlunderSpec type for use with
@@ -50,3 +51,11 @@ func (b *FlunderSpecApplyConfiguration) WithReferenceType(value wardlev1alpha1.R
 	b.ReferenceType = &value
 	return b
 }
+
+// WithOwnershipPolicy sets the OwnershipPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OwnershipPolicy field is set to the value of the last call.
+func (b *FlunderSpecApplyConfiguration) WithOwnershipPolicy(value wardlev1alpha1.OwnershipPolicy) *FlunderSpecApplyConfiguration {
+	b.OwnershipPolicy = &value
+	return b
+}
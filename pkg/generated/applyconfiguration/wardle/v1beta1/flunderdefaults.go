@@ -0,0 +1,91 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	wardlev1beta1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+)
+
+// FlunderDefaultsApplyConfiguration represents a declarative configuration of the FlunderDefaults type for use
+// with apply.
+type FlunderDefaultsApplyConfiguration struct {
+	NamePattern   *string                      `json:"namePattern,omitempty"`
+	Labels        map[string]string            `json:"labels,omitempty"`
+	Annotations   map[string]string            `json:"annotations,omitempty"`
+	Reference     *string                      `json:"reference,omitempty"`
+	ReferenceType *wardlev1beta1.ReferenceType `json:"referenceType,omitempty"`
+}
+
+// FlunderDefaultsApplyConfiguration constructs a declarative configuration of the FlunderDefaults type for use with
+// apply.
+func FlunderDefaults() *FlunderDefaultsApplyConfiguration {
+	return &FlunderDefaultsApplyConfiguration{}
+}
+
+// WithNamePattern sets the NamePattern field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamePattern field is set to the value of the last call.
+func (b *FlunderDefaultsApplyConfiguration) WithNamePattern(value string) *FlunderDefaultsApplyConfiguration {
+	b.NamePattern = &value
+	return b
+}
+
+// WithLabels puts the entries into the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Labels field,
+// overwriting an existing map entries in Labels field with the same key.
+func (b *FlunderDefaultsApplyConfiguration) WithLabels(entries map[string]string) *FlunderDefaultsApplyConfiguration {
+	if b.Labels == nil && len(entries) > 0 {
+		b.Labels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Labels[k] = v
+	}
+	return b
+}
+
+// WithAnnotations puts the entries into the Annotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Annotations field,
+// overwriting an existing map entries in Annotations field with the same key.
+func (b *FlunderDefaultsApplyConfiguration) WithAnnotations(entries map[string]string) *FlunderDefaultsApplyConfiguration {
+	if b.Annotations == nil && len(entries) > 0 {
+		b.Annotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Annotations[k] = v
+	}
+	return b
+}
+
+// WithReference sets the Reference field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reference field is set to the value of the last call.
+func (b *FlunderDefaultsApplyConfiguration) WithReference(value string) *FlunderDefaultsApplyConfiguration {
+	b.Reference = &value
+	return b
+}
+
+// WithReferenceType sets the ReferenceType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReferenceType field is set to the value of the last call.
+func (b *FlunderDefaultsApplyConfiguration) WithReferenceType(value wardlev1beta1.ReferenceType) *FlunderDefaultsApplyConfiguration {
+	b.ReferenceType = &value
+	return b
+}
@@ -25,9 +25,10 @@ import (
 // FlunderSpecApplyConfiguration represents a declarative configuration of the FlunderSpec type for use
 // with apply.
 type FlunderSpecApplyConfiguration struct {
-	FlunderReference *string                      `json:"flunderReference,omitempty"`
-	FischerReference *string                      `json:"fischerReference,omitempty"`
-	ReferenceType    *wardlev1beta1.ReferenceType `json:"referenceType,omitempty"`
+	FlunderReference *string                        `json:"flunderReference,omitempty"`
+	FischerReference *string                        `json:"fischerReference,omitempty"`
+	ReferenceType    *wardlev1beta1.ReferenceType   `json:"referenceType,omitempty"`
+	OwnershipPolicy  *wardlev1beta1.OwnershipPolicy `json:"ownershipPolicy,omitempty"`
 }
 
 // FlunderSpecApplyConfiguration constructs a declarative configuration of the FlunderSpec type for use with
@@ -59,3 +60,11 @@ func (b *FlunderSpecApplyConfiguration) WithReferenceType(value wardlev1beta1.Re
 	b.ReferenceType = &value
 	return b
 }
+
+// WithOwnershipPolicy sets the OwnershipPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OwnershipPolicy field is set to the value of the last call.
+func (b *FlunderSpecApplyConfiguration) WithOwnershipPolicy(value wardlev1beta1.OwnershipPolicy) *FlunderSpecApplyConfiguration {
+	b.OwnershipPolicy = &value
+	return b
+}
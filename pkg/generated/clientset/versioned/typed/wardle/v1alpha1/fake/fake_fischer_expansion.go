@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	context "context"
+
+	v1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	clientexpansion "k8s.io/sample-apiserver/pkg/clientexpansion"
+	wardlev1alpha1 "k8s.io/sample-apiserver/pkg/generated/clientset/versioned/typed/wardle/v1alpha1"
+)
+
+func (c *FakeFischers) BanName(ctx context.Context, name, flunderName string) (*v1alpha1.Fischer, error) {
+	return clientexpansion.Update[*v1alpha1.Fischer](ctx, c, name, wardlev1alpha1.BanNameFunc(flunderName))
+}
+
+func (c *FakeFischers) UnbanName(ctx context.Context, name, flunderName string) (*v1alpha1.Fischer, error) {
+	return clientexpansion.Update[*v1alpha1.Fischer](ctx, c, name, wardlev1alpha1.UnbanNameFunc(flunderName))
+}
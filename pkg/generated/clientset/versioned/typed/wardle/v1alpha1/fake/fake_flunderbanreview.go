@@ -0,0 +1,47 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	context "context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+)
+
+// FakeFlunderBanReviews implements FlunderBanReviewInterface
+type FakeFlunderBanReviews struct {
+	Fake *FakeWardleV1alpha1
+}
+
+var flunderbanreviewsResource = v1alpha1.SchemeGroupVersion.WithResource("flunderbanreviews")
+
+var flunderbanreviewsKind = v1alpha1.SchemeGroupVersion.WithKind("FlunderBanReview")
+
+// Create takes the representation of a flunderBanReview and creates it.  Returns the server's representation of the flunderBanReview, and an error, if there is any.
+func (c *FakeFlunderBanReviews) Create(ctx context.Context, flunderBanReview *v1alpha1.FlunderBanReview, opts v1.CreateOptions) (result *v1alpha1.FlunderBanReview, err error) {
+	emptyResult := &v1alpha1.FlunderBanReview{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateActionWithOptions(flunderbanreviewsResource, flunderBanReview, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderBanReview), err
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	context "context"
+	json "encoding/json"
+	fmt "fmt"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	wardlev1alpha1 "k8s.io/sample-apiserver/pkg/generated/applyconfiguration/wardle/v1alpha1"
+)
+
+// FakeFlunderPolicies implements FlunderPolicyInterface
+type FakeFlunderPolicies struct {
+	Fake *FakeWardleV1alpha1
+	ns   string
+}
+
+var flunderpoliciesResource = v1alpha1.SchemeGroupVersion.WithResource("flunderpolicies")
+
+var flunderpoliciesKind = v1alpha1.SchemeGroupVersion.WithKind("FlunderPolicy")
+
+// Get takes name of the flunderPolicy, and returns the corresponding flunderPolicy object, and an error if there is any.
+func (c *FakeFlunderPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.FlunderPolicy, err error) {
+	emptyResult := &v1alpha1.FlunderPolicy{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(flunderpoliciesResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of FlunderPolicies that match those selectors.
+func (c *FakeFlunderPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.FlunderPolicyList, err error) {
+	emptyResult := &v1alpha1.FlunderPolicyList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(flunderpoliciesResource, flunderpoliciesKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.FlunderPolicyList{ListMeta: obj.(*v1alpha1.FlunderPolicyList).ListMeta}
+	for _, item := range obj.(*v1alpha1.FlunderPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested flunderPolicies.
+func (c *FakeFlunderPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(flunderpoliciesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a flunderPolicy and creates it.  Returns the server's representation of the flunderPolicy, and an error, if there is any.
+func (c *FakeFlunderPolicies) Create(ctx context.Context, flunderPolicy *v1alpha1.FlunderPolicy, opts v1.CreateOptions) (result *v1alpha1.FlunderPolicy, err error) {
+	emptyResult := &v1alpha1.FlunderPolicy{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(flunderpoliciesResource, c.ns, flunderPolicy, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderPolicy), err
+}
+
+// Update takes the representation of a flunderPolicy and updates it. Returns the server's representation of the flunderPolicy, and an error, if there is any.
+func (c *FakeFlunderPolicies) Update(ctx context.Context, flunderPolicy *v1alpha1.FlunderPolicy, opts v1.UpdateOptions) (result *v1alpha1.FlunderPolicy, err error) {
+	emptyResult := &v1alpha1.FlunderPolicy{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(flunderpoliciesResource, c.ns, flunderPolicy, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderPolicy), err
+}
+
+// Delete takes name of the flunderPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeFlunderPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(flunderpoliciesResource, c.ns, name, opts), &v1alpha1.FlunderPolicy{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeFlunderPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(flunderpoliciesResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.FlunderPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched flunderPolicy.
+func (c *FakeFlunderPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.FlunderPolicy, err error) {
+	emptyResult := &v1alpha1.FlunderPolicy{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(flunderpoliciesResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderPolicy), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied flunderPolicy.
+func (c *FakeFlunderPolicies) Apply(ctx context.Context, flunderPolicy *wardlev1alpha1.FlunderPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.FlunderPolicy, err error) {
+	if flunderPolicy == nil {
+		return nil, fmt.Errorf("flunderPolicy provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(flunderPolicy)
+	if err != nil {
+		return nil, err
+	}
+	name := flunderPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("flunderPolicy.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.FlunderPolicy{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(flunderpoliciesResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderPolicy), err
+}
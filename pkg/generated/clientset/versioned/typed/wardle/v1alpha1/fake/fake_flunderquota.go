@@ -0,0 +1,197 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	context "context"
+	json "encoding/json"
+	fmt "fmt"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	wardlev1alpha1 "k8s.io/sample-apiserver/pkg/generated/applyconfiguration/wardle/v1alpha1"
+)
+
+// FakeFlunderQuotas implements FlunderQuotaInterface
+type FakeFlunderQuotas struct {
+	Fake *FakeWardleV1alpha1
+	ns   string
+}
+
+var flunderquotasResource = v1alpha1.SchemeGroupVersion.WithResource("flunderquotas")
+
+var flunderquotasKind = v1alpha1.SchemeGroupVersion.WithKind("FlunderQuota")
+
+// Get takes name of the flunderQuota, and returns the corresponding flunderQuota object, and an error if there is any.
+func (c *FakeFlunderQuotas) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.FlunderQuota, err error) {
+	emptyResult := &v1alpha1.FlunderQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(flunderquotasResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderQuota), err
+}
+
+// List takes label and field selectors, and returns the list of FlunderQuotas that match those selectors.
+func (c *FakeFlunderQuotas) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.FlunderQuotaList, err error) {
+	emptyResult := &v1alpha1.FlunderQuotaList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(flunderquotasResource, flunderquotasKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.FlunderQuotaList{ListMeta: obj.(*v1alpha1.FlunderQuotaList).ListMeta}
+	for _, item := range obj.(*v1alpha1.FlunderQuotaList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested flunderQuotas.
+func (c *FakeFlunderQuotas) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(flunderquotasResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a flunderQuota and creates it.  Returns the server's representation of the flunderQuota, and an error, if there is any.
+func (c *FakeFlunderQuotas) Create(ctx context.Context, flunderQuota *v1alpha1.FlunderQuota, opts v1.CreateOptions) (result *v1alpha1.FlunderQuota, err error) {
+	emptyResult := &v1alpha1.FlunderQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(flunderquotasResource, c.ns, flunderQuota, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderQuota), err
+}
+
+// Update takes the representation of a flunderQuota and updates it. Returns the server's representation of the flunderQuota, and an error, if there is any.
+func (c *FakeFlunderQuotas) Update(ctx context.Context, flunderQuota *v1alpha1.FlunderQuota, opts v1.UpdateOptions) (result *v1alpha1.FlunderQuota, err error) {
+	emptyResult := &v1alpha1.FlunderQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(flunderquotasResource, c.ns, flunderQuota, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderQuota), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeFlunderQuotas) UpdateStatus(ctx context.Context, flunderQuota *v1alpha1.FlunderQuota, opts v1.UpdateOptions) (result *v1alpha1.FlunderQuota, err error) {
+	emptyResult := &v1alpha1.FlunderQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(flunderquotasResource, "status", c.ns, flunderQuota, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderQuota), err
+}
+
+// Delete takes name of the flunderQuota and deletes it. Returns an error if one occurs.
+func (c *FakeFlunderQuotas) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(flunderquotasResource, c.ns, name, opts), &v1alpha1.FlunderQuota{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeFlunderQuotas) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(flunderquotasResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.FlunderQuotaList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched flunderQuota.
+func (c *FakeFlunderQuotas) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.FlunderQuota, err error) {
+	emptyResult := &v1alpha1.FlunderQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(flunderquotasResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderQuota), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied flunderQuota.
+func (c *FakeFlunderQuotas) Apply(ctx context.Context, flunderQuota *wardlev1alpha1.FlunderQuotaApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.FlunderQuota, err error) {
+	if flunderQuota == nil {
+		return nil, fmt.Errorf("flunderQuota provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(flunderQuota)
+	if err != nil {
+		return nil, err
+	}
+	name := flunderQuota.Name
+	if name == nil {
+		return nil, fmt.Errorf("flunderQuota.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.FlunderQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(flunderquotasResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderQuota), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeFlunderQuotas) ApplyStatus(ctx context.Context, flunderQuota *wardlev1alpha1.FlunderQuotaApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.FlunderQuota, err error) {
+	if flunderQuota == nil {
+		return nil, fmt.Errorf("flunderQuota provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(flunderQuota)
+	if err != nil {
+		return nil, err
+	}
+	name := flunderQuota.Name
+	if name == nil {
+		return nil, fmt.Errorf("flunderQuota.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.FlunderQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(flunderquotasResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.FlunderQuota), err
+}
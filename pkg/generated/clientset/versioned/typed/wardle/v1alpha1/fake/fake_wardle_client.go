@@ -36,6 +36,18 @@ func (c *FakeWardleV1alpha1) Flunders(namespace string) v1alpha1.FlunderInterfac
 	return &FakeFlunders{c, namespace}
 }
 
+func (c *FakeWardleV1alpha1) FlunderBanReviews() v1alpha1.FlunderBanReviewInterface {
+	return &FakeFlunderBanReviews{c}
+}
+
+func (c *FakeWardleV1alpha1) FlunderPolicies(namespace string) v1alpha1.FlunderPolicyInterface {
+	return &FakeFlunderPolicies{c, namespace}
+}
+
+func (c *FakeWardleV1alpha1) FlunderQuotas(namespace string) v1alpha1.FlunderQuotaInterface {
+	return &FakeFlunderQuotas{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeWardleV1alpha1) RESTClient() rest.Interface {
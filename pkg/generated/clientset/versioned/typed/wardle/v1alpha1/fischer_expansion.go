@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	context "context"
+
+	wardlev1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	clientexpansion "k8s.io/sample-apiserver/pkg/clientexpansion"
+)
+
+// FischerExpansion has additional methods to work with Fischer resources.
+type FischerExpansion interface {
+	// BanName adds flunderName to the disallowed Flunders of the named
+	// Fischer, unless it is there already. Conflicting updates are retried.
+	BanName(ctx context.Context, name, flunderName string) (*wardlev1alpha1.Fischer, error)
+	// UnbanName removes flunderName from the disallowed Flunders of the named
+	// Fischer. Conflicting updates are retried.
+	UnbanName(ctx context.Context, name, flunderName string) (*wardlev1alpha1.Fischer, error)
+}
+
+func (c *fischers) BanName(ctx context.Context, name, flunderName string) (*wardlev1alpha1.Fischer, error) {
+	return clientexpansion.Update[*wardlev1alpha1.Fischer](ctx, c, name, BanNameFunc(flunderName))
+}
+
+func (c *fischers) UnbanName(ctx context.Context, name, flunderName string) (*wardlev1alpha1.Fischer, error) {
+	return clientexpansion.Update[*wardlev1alpha1.Fischer](ctx, c, name, UnbanNameFunc(flunderName))
+}
+
+// BanNameFunc returns a function which adds flunderName to the disallowed
+// Flunders of a Fischer, and returns whether the Fischer changed.
+func BanNameFunc(flunderName string) func(*wardlev1alpha1.Fischer) bool {
+	return func(fischer *wardlev1alpha1.Fischer) bool {
+		for _, disallowed := range fischer.DisallowedFlunders {
+			if disallowed == flunderName {
+				return false
+			}
+		}
+		fischer.DisallowedFlunders = append(fischer.DisallowedFlunders, flunderName)
+		return true
+	}
+}
+
+// UnbanNameFunc returns a function which removes flunderName from the
+// disallowed Flunders of a Fischer, and returns whether the Fischer changed.
+func UnbanNameFunc(flunderName string) func(*wardlev1alpha1.Fischer) bool {
+	return func(fischer *wardlev1alpha1.Fischer) bool {
+		disallowed := fischer.DisallowedFlunders[:0]
+		for _, n := range fischer.DisallowedFlunders {
+			if n != flunderName {
+				disallowed = append(disallowed, n)
+			}
+		}
+		changed := len(disallowed) != len(fischer.DisallowedFlunders)
+		fischer.DisallowedFlunders = disallowed
+		return changed
+	}
+}
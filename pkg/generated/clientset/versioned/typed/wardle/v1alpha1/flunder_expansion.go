@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	context "context"
+
+	wardlev1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	clientexpansion "k8s.io/sample-apiserver/pkg/clientexpansion"
+)
+
+// FlunderExpansion has additional methods to work with Flunder resources.
+type FlunderExpansion interface {
+	// ResolveReference returns the named Flunder followed by the Flunders it
+	// references transitively. The last Flunder does not reference another
+	// Flunder, but possibly a Fischer.
+	ResolveReference(ctx context.Context, name string) ([]*wardlev1alpha1.Flunder, error)
+	// WaitForCondition watches the named Flunder until condition, e.g. a check
+	// of its status, returns true or an error, and returns the last seen
+	// Flunder.
+	WaitForCondition(ctx context.Context, name string, condition func(*wardlev1alpha1.Flunder) (bool, error)) (*wardlev1alpha1.Flunder, error)
+}
+
+func (c *flunders) ResolveReference(ctx context.Context, name string) ([]*wardlev1alpha1.Flunder, error) {
+	return clientexpansion.ResolveChain[*wardlev1alpha1.Flunder](ctx, c, name, FlunderReference)
+}
+
+func (c *flunders) WaitForCondition(ctx context.Context, name string, condition func(*wardlev1alpha1.Flunder) (bool, error)) (*wardlev1alpha1.Flunder, error) {
+	return clientexpansion.WaitFor[*wardlev1alpha1.Flunder](ctx, c, wardlev1alpha1.Resource("flunders"), name, condition)
+}
+
+// FlunderReference returns the name of the Flunder referenced by the given
+// Flunder, or an empty name if it references none.
+func FlunderReference(flunder *wardlev1alpha1.Flunder) string {
+	if flunder.Spec.ReferenceType != nil && *flunder.Spec.ReferenceType != wardlev1alpha1.FlunderReferenceType {
+		return ""
+	}
+	return flunder.Spec.Reference
+}
@@ -0,0 +1,59 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gentype "k8s.io/client-go/gentype"
+	wardlev1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	scheme "k8s.io/sample-apiserver/pkg/generated/clientset/versioned/scheme"
+)
+
+// FlunderBanReviewsGetter has a method to return a FlunderBanReviewInterface.
+// A group's client should implement this interface.
+type FlunderBanReviewsGetter interface {
+	FlunderBanReviews() FlunderBanReviewInterface
+}
+
+// FlunderBanReviewInterface has methods to work with FlunderBanReview resources.
+type FlunderBanReviewInterface interface {
+	Create(ctx context.Context, flunderBanReview *wardlev1alpha1.FlunderBanReview, opts v1.CreateOptions) (*wardlev1alpha1.FlunderBanReview, error)
+	FlunderBanReviewExpansion
+}
+
+// flunderBanReviews implements FlunderBanReviewInterface
+type flunderBanReviews struct {
+	*gentype.Client[*wardlev1alpha1.FlunderBanReview]
+}
+
+// newFlunderBanReviews returns a FlunderBanReviews
+func newFlunderBanReviews(c *WardleV1alpha1Client) *flunderBanReviews {
+	return &flunderBanReviews{
+		gentype.NewClient[*wardlev1alpha1.FlunderBanReview](
+			"flunderbanreviews",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *wardlev1alpha1.FlunderBanReview { return &wardlev1alpha1.FlunderBanReview{} },
+			gentype.PrefersProtobuf[*wardlev1alpha1.FlunderBanReview](),
+		),
+	}
+}
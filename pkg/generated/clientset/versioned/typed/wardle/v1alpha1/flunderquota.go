@@ -0,0 +1,75 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+	wardlev1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	applyconfigurationwardlev1alpha1 "k8s.io/sample-apiserver/pkg/generated/applyconfiguration/wardle/v1alpha1"
+	scheme "k8s.io/sample-apiserver/pkg/generated/clientset/versioned/scheme"
+)
+
+// FlunderQuotasGetter has a method to return a FlunderQuotaInterface.
+// A group's client should implement this interface.
+type FlunderQuotasGetter interface {
+	FlunderQuotas(namespace string) FlunderQuotaInterface
+}
+
+// FlunderQuotaInterface has methods to work with FlunderQuota resources.
+type FlunderQuotaInterface interface {
+	Create(ctx context.Context, flunderQuota *wardlev1alpha1.FlunderQuota, opts v1.CreateOptions) (*wardlev1alpha1.FlunderQuota, error)
+	Update(ctx context.Context, flunderQuota *wardlev1alpha1.FlunderQuota, opts v1.UpdateOptions) (*wardlev1alpha1.FlunderQuota, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, flunderQuota *wardlev1alpha1.FlunderQuota, opts v1.UpdateOptions) (*wardlev1alpha1.FlunderQuota, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*wardlev1alpha1.FlunderQuota, error)
+	List(ctx context.Context, opts v1.ListOptions) (*wardlev1alpha1.FlunderQuotaList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *wardlev1alpha1.FlunderQuota, err error)
+	Apply(ctx context.Context, flunderQuota *applyconfigurationwardlev1alpha1.FlunderQuotaApplyConfiguration, opts v1.ApplyOptions) (result *wardlev1alpha1.FlunderQuota, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, flunderQuota *applyconfigurationwardlev1alpha1.FlunderQuotaApplyConfiguration, opts v1.ApplyOptions) (result *wardlev1alpha1.FlunderQuota, err error)
+	FlunderQuotaExpansion
+}
+
+// flunderQuotas implements FlunderQuotaInterface
+type flunderQuotas struct {
+	*gentype.ClientWithListAndApply[*wardlev1alpha1.FlunderQuota, *wardlev1alpha1.FlunderQuotaList, *applyconfigurationwardlev1alpha1.FlunderQuotaApplyConfiguration]
+}
+
+// newFlunderQuotas returns a FlunderQuotas
+func newFlunderQuotas(c *WardleV1alpha1Client, namespace string) *flunderQuotas {
+	return &flunderQuotas{
+		gentype.NewClientWithListAndApply[*wardlev1alpha1.FlunderQuota, *wardlev1alpha1.FlunderQuotaList, *applyconfigurationwardlev1alpha1.FlunderQuotaApplyConfiguration](
+			"flunderquotas",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *wardlev1alpha1.FlunderQuota { return &wardlev1alpha1.FlunderQuota{} },
+			func() *wardlev1alpha1.FlunderQuotaList { return &wardlev1alpha1.FlunderQuotaList{} },
+			gentype.PrefersProtobuf[*wardlev1alpha1.FlunderQuota](),
+		),
+	}
+}
@@ -18,6 +18,8 @@ limitations under the License.
 
 package v1alpha1
 
-type FischerExpansion interface{}
+type FlunderBanReviewExpansion interface{}
 
-type FlunderExpansion interface{}
+type FlunderPolicyExpansion interface{}
+
+type FlunderQuotaExpansion interface{}
@@ -30,6 +30,9 @@ type WardleV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	FischersGetter
 	FlundersGetter
+	FlunderBanReviewsGetter
+	FlunderPoliciesGetter
+	FlunderQuotasGetter
 }
 
 // WardleV1alpha1Client is used to interact with features provided by the wardle.example.com group.
@@ -45,6 +48,18 @@ func (c *WardleV1alpha1Client) Flunders(namespace string) FlunderInterface {
 	return newFlunders(c, namespace)
 }
 
+func (c *WardleV1alpha1Client) FlunderBanReviews() FlunderBanReviewInterface {
+	return newFlunderBanReviews(c)
+}
+
+func (c *WardleV1alpha1Client) FlunderPolicies(namespace string) FlunderPolicyInterface {
+	return newFlunderPolicies(c, namespace)
+}
+
+func (c *WardleV1alpha1Client) FlunderQuotas(namespace string) FlunderQuotaInterface {
+	return newFlunderQuotas(c, namespace)
+}
+
 // NewForConfig creates a new WardleV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	context "context"
+
+	v1beta1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+	clientexpansion "k8s.io/sample-apiserver/pkg/clientexpansion"
+	wardlev1beta1 "k8s.io/sample-apiserver/pkg/generated/clientset/versioned/typed/wardle/v1beta1"
+)
+
+func (c *FakeFlunders) ResolveReference(ctx context.Context, name string) ([]*v1beta1.Flunder, error) {
+	return clientexpansion.ResolveChain[*v1beta1.Flunder](ctx, c, name, wardlev1beta1.FlunderReference)
+}
+
+func (c *FakeFlunders) WaitForCondition(ctx context.Context, name string, condition func(*v1beta1.Flunder) (bool, error)) (*v1beta1.Flunder, error) {
+	return clientexpansion.WaitFor[*v1beta1.Flunder](ctx, c, v1beta1.Resource("flunders"), name, condition)
+}
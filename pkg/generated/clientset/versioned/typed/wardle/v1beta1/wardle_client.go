@@ -28,6 +28,7 @@ import (
 
 type WardleV1beta1Interface interface {
 	RESTClient() rest.Interface
+	FischersGetter
 	FlundersGetter
 }
 
@@ -36,6 +37,10 @@ type WardleV1beta1Client struct {
 	restClient rest.Interface
 }
 
+func (c *WardleV1beta1Client) Fischers() FischerInterface {
+	return newFischers(c)
+}
+
 func (c *WardleV1beta1Client) Flunders(namespace string) FlunderInterface {
 	return newFlunders(c, namespace)
 }
@@ -58,8 +58,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Wardle().V1alpha1().Fischers().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("flunders"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Wardle().V1alpha1().Flunders().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("flunderpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Wardle().V1alpha1().FlunderPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("flunderquotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Wardle().V1alpha1().FlunderQuotas().Informer()}, nil
 
 		// Group=wardle.example.com, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("fischers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Wardle().V1beta1().Fischers().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("flunders"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Wardle().V1beta1().Flunders().Informer()}, nil
 
@@ -0,0 +1,90 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+	apiswardlev1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	versioned "k8s.io/sample-apiserver/pkg/generated/clientset/versioned"
+	internalinterfaces "k8s.io/sample-apiserver/pkg/generated/informers/externalversions/internalinterfaces"
+	wardlev1alpha1 "k8s.io/sample-apiserver/pkg/generated/listers/wardle/v1alpha1"
+)
+
+// FlunderPolicyInformer provides access to a shared informer and lister for
+// FlunderPolicies.
+type FlunderPolicyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() wardlev1alpha1.FlunderPolicyLister
+}
+
+type flunderPolicyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewFlunderPolicyInformer constructs a new informer for FlunderPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFlunderPolicyInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredFlunderPolicyInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredFlunderPolicyInformer constructs a new informer for FlunderPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredFlunderPolicyInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WardleV1alpha1().FlunderPolicies(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WardleV1alpha1().FlunderPolicies(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&apiswardlev1alpha1.FlunderPolicy{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *flunderPolicyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredFlunderPolicyInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *flunderPolicyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apiswardlev1alpha1.FlunderPolicy{}, f.defaultInformer)
+}
+
+func (f *flunderPolicyInformer) Lister() wardlev1alpha1.FlunderPolicyLister {
+	return wardlev1alpha1.NewFlunderPolicyLister(f.Informer().GetIndexer())
+}
@@ -28,6 +28,10 @@ type Interface interface {
 	Fischers() FischerInformer
 	// Flunders returns a FlunderInformer.
 	Flunders() FlunderInformer
+	// FlunderPolicies returns a FlunderPolicyInformer.
+	FlunderPolicies() FlunderPolicyInformer
+	// FlunderQuotas returns a FlunderQuotaInformer.
+	FlunderQuotas() FlunderQuotaInformer
 }
 
 type version struct {
@@ -50,3 +54,13 @@ func (v *version) Fischers() FischerInformer {
 func (v *version) Flunders() FlunderInformer {
 	return &flunderInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// FlunderPolicies returns a FlunderPolicyInformer.
+func (v *version) FlunderPolicies() FlunderPolicyInformer {
+	return &flunderPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// FlunderQuotas returns a FlunderQuotaInformer.
+func (v *version) FlunderQuotas() FlunderQuotaInformer {
+	return &flunderQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
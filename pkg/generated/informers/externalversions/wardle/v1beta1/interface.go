@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// Fischers returns a FischerInformer.
+	Fischers() FischerInformer
 	// Flunders returns a FlunderInformer.
 	Flunders() FlunderInformer
 }
@@ -39,6 +41,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// Fischers returns a FischerInformer.
+func (v *version) Fischers() FischerInformer {
+	return &fischerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Flunders returns a FlunderInformer.
 func (v *version) Flunders() FlunderInformer {
 	return &flunderInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
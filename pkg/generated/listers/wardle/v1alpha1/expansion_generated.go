@@ -29,3 +29,19 @@ type FlunderListerExpansion interface{}
 // FlunderNamespaceListerExpansion allows custom methods to be added to
 // FlunderNamespaceLister.
 type FlunderNamespaceListerExpansion interface{}
+
+// FlunderPolicyListerExpansion allows custom methods to be added to
+// FlunderPolicyLister.
+type FlunderPolicyListerExpansion interface{}
+
+// FlunderPolicyNamespaceListerExpansion allows custom methods to be added to
+// FlunderPolicyNamespaceLister.
+type FlunderPolicyNamespaceListerExpansion interface{}
+
+// FlunderQuotaListerExpansion allows custom methods to be added to
+// FlunderQuotaLister.
+type FlunderQuotaListerExpansion interface{}
+
+// FlunderQuotaNamespaceListerExpansion allows custom methods to be added to
+// FlunderQuotaNamespaceLister.
+type FlunderQuotaNamespaceListerExpansion interface{}
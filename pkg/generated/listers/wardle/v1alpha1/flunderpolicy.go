@@ -0,0 +1,70 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+	wardlev1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+)
+
+// FlunderPolicyLister helps list FlunderPolicies.
+// All objects returned here must be treated as read-only.
+type FlunderPolicyLister interface {
+	// List lists all FlunderPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*wardlev1alpha1.FlunderPolicy, err error)
+	// FlunderPolicies returns an object that can list and get FlunderPolicies.
+	FlunderPolicies(namespace string) FlunderPolicyNamespaceLister
+	FlunderPolicyListerExpansion
+}
+
+// flunderPolicyLister implements the FlunderPolicyLister interface.
+type flunderPolicyLister struct {
+	listers.ResourceIndexer[*wardlev1alpha1.FlunderPolicy]
+}
+
+// NewFlunderPolicyLister returns a new FlunderPolicyLister.
+func NewFlunderPolicyLister(indexer cache.Indexer) FlunderPolicyLister {
+	return &flunderPolicyLister{listers.New[*wardlev1alpha1.FlunderPolicy](indexer, wardlev1alpha1.Resource("flunderpolicy"))}
+}
+
+// FlunderPolicies returns an object that can list and get FlunderPolicies.
+func (s *flunderPolicyLister) FlunderPolicies(namespace string) FlunderPolicyNamespaceLister {
+	return flunderPolicyNamespaceLister{listers.NewNamespaced[*wardlev1alpha1.FlunderPolicy](s.ResourceIndexer, namespace)}
+}
+
+// FlunderPolicyNamespaceLister helps list and get FlunderPolicies.
+// All objects returned here must be treated as read-only.
+type FlunderPolicyNamespaceLister interface {
+	// List lists all FlunderPolicies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*wardlev1alpha1.FlunderPolicy, err error)
+	// Get retrieves the FlunderPolicy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*wardlev1alpha1.FlunderPolicy, error)
+	FlunderPolicyNamespaceListerExpansion
+}
+
+// flunderPolicyNamespaceLister implements the FlunderPolicyNamespaceLister
+// interface.
+type flunderPolicyNamespaceLister struct {
+	listers.ResourceIndexer[*wardlev1alpha1.FlunderPolicy]
+}
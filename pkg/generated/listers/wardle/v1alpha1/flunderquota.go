@@ -0,0 +1,70 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+	wardlev1alpha1 "k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+)
+
+// FlunderQuotaLister helps list FlunderQuotas.
+// All objects returned here must be treated as read-only.
+type FlunderQuotaLister interface {
+	// List lists all FlunderQuotas in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*wardlev1alpha1.FlunderQuota, err error)
+	// FlunderQuotas returns an object that can list and get FlunderQuotas.
+	FlunderQuotas(namespace string) FlunderQuotaNamespaceLister
+	FlunderQuotaListerExpansion
+}
+
+// flunderQuotaLister implements the FlunderQuotaLister interface.
+type flunderQuotaLister struct {
+	listers.ResourceIndexer[*wardlev1alpha1.FlunderQuota]
+}
+
+// NewFlunderQuotaLister returns a new FlunderQuotaLister.
+func NewFlunderQuotaLister(indexer cache.Indexer) FlunderQuotaLister {
+	return &flunderQuotaLister{listers.New[*wardlev1alpha1.FlunderQuota](indexer, wardlev1alpha1.Resource("flunderquota"))}
+}
+
+// FlunderQuotas returns an object that can list and get FlunderQuotas.
+func (s *flunderQuotaLister) FlunderQuotas(namespace string) FlunderQuotaNamespaceLister {
+	return flunderQuotaNamespaceLister{listers.NewNamespaced[*wardlev1alpha1.FlunderQuota](s.ResourceIndexer, namespace)}
+}
+
+// FlunderQuotaNamespaceLister helps list and get FlunderQuotas.
+// All objects returned here must be treated as read-only.
+type FlunderQuotaNamespaceLister interface {
+	// List lists all FlunderQuotas in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*wardlev1alpha1.FlunderQuota, err error)
+	// Get retrieves the FlunderQuota from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*wardlev1alpha1.FlunderQuota, error)
+	FlunderQuotaNamespaceListerExpansion
+}
+
+// flunderQuotaNamespaceLister implements the FlunderQuotaNamespaceLister
+// interface.
+type flunderQuotaNamespaceLister struct {
+	listers.ResourceIndexer[*wardlev1alpha1.FlunderQuota]
+}
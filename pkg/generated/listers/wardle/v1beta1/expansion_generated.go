@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1beta1
 
+// FischerListerExpansion allows custom methods to be added to
+// FischerLister.
+type FischerListerExpansion interface{}
+
 // FlunderListerExpansion allows custom methods to be added to
 // FlunderLister.
 type FlunderListerExpansion interface{}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quota provides the quota evaluators of the wardle resources, which
+// are shared by the FlunderQuota admission plugin and the FlunderQuota
+// controller.
+package quota
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	quota "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/apiserver/pkg/quota/v1/generic"
+	clientinformers "k8s.io/client-go/informers"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+)
+
+// NewEvaluators returns the evaluators of the wardle resources a FlunderQuota
+// can limit. They count the objects in the informers of f, which must be
+// started before the evaluators are used. Until the informers have synced, the
+// evaluators fail to calculate the usage.
+func NewEvaluators(f informers.SharedInformerFactory) []quota.Evaluator {
+	// request the informers before the factory is started
+	f.Wardle().V1alpha1().Flunders().Informer()
+
+	listers := generic.ListerFuncForResourceFunc(func(gvr schema.GroupVersionResource) (clientinformers.GenericInformer, error) {
+		return f.ForResource(gvr)
+	})
+	flunders := v1alpha1.SchemeGroupVersion.WithResource("flunders")
+	return []quota.Evaluator{
+		generic.NewObjectCountEvaluator(flunders.GroupResource(), generic.ListResourceUsingListerFunc(listers, flunders), ""),
+	}
+}
+
+// NewRegistry returns a registry of the evaluators returned by NewEvaluators.
+func NewRegistry(f informers.SharedInformerFactory) quota.Registry {
+	return generic.NewRegistry(NewEvaluators(f))
+}
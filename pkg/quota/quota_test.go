@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	quota "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/fake"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+)
+
+func TestFlunderEvaluator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs := fake.NewSimpleClientset(
+		&v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team"}},
+		&v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "team"}},
+		&v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "other"}},
+	)
+	factory := informers.NewSharedInformerFactory(cs, 0)
+	registry := NewRegistry(factory)
+
+	evaluator := registry.Get(wardle.Resource("flunders"))
+	require.NotNil(t, evaluator)
+	resources := []corev1.ResourceName{v1alpha1.ResourceFlunders}
+	assert.Equal(t, resources, evaluator.MatchingResources([]corev1.ResourceName{v1alpha1.ResourceFlunders, "count/fischers.wardle.example.com"}),
+		"the evaluator must count the resource of FlunderQuotas")
+
+	_, err := evaluator.UsageStats(quota.UsageStatsOptions{Namespace: "team", Resources: resources})
+	assert.Error(t, err, "usage must not be calculated before the informers have synced")
+
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+	stats, err := evaluator.UsageStats(quota.UsageStatsOptions{Namespace: "team", Resources: resources})
+	require.NoError(t, err)
+	used := stats.Used[v1alpha1.ResourceFlunders]
+	assert.Equal(t, int64(2), used.Value())
+}
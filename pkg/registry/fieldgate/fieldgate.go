@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fieldgate drops the fields of disabled features from written
+// objects, for the PrepareForCreate and PrepareForUpdate methods of the
+// registry strategies. A field of a disabled feature is cleared on create, and
+// on update unless the old object already sets it, so that objects written
+// while the feature was enabled keep their value.
+package fieldgate
+
+import (
+	"reflect"
+
+	"k8s.io/component-base/featuregate"
+)
+
+// Field is a field of objects of type T which is gated by a feature.
+type Field[T any] struct {
+	feature featuregate.Feature
+	inUse   func(obj T) bool
+	clear   func(obj T)
+}
+
+// New returns the field of objects of type T which get returns a pointer to,
+// gated by feature. The field is in use if it is not the zero value, or for
+// slices and maps, if it is not empty.
+func New[T any, F any](feature featuregate.Feature, get func(obj T) *F) Field[T] {
+	return Field[T]{
+		feature: feature,
+		inUse: func(obj T) bool {
+			v := reflect.ValueOf(get(obj)).Elem()
+			switch v.Kind() {
+			case reflect.Slice, reflect.Map:
+				return v.Len() != 0
+			default:
+				return !v.IsZero()
+			}
+		},
+		clear: func(obj T) {
+			var zero F
+			*get(obj) = zero
+		},
+	}
+}
+
+// DropOnCreate clears the fields of obj whose feature is disabled by
+// featureGate. No fields are cleared if featureGate is nil.
+func DropOnCreate[T any](featureGate featuregate.FeatureGate, obj T, fields ...Field[T]) {
+	if featureGate == nil {
+		return
+	}
+	for _, f := range fields {
+		if !featureGate.Enabled(f.feature) {
+			f.clear(obj)
+		}
+	}
+}
+
+// DropOnUpdate clears the fields of newObj whose feature is disabled by
+// featureGate, unless oldObj sets them. No fields are cleared if featureGate
+// is nil.
+func DropOnUpdate[T any](featureGate featuregate.FeatureGate, newObj, oldObj T, fields ...Field[T]) {
+	if featureGate == nil {
+		return
+	}
+	for _, f := range fields {
+		if !featureGate.Enabled(f.feature) && !f.inUse(oldObj) {
+			f.clear(newObj)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// graduated is alpha in 1.0, beta in 1.1 and GA in 1.2, like BanFlunder.
+	graduated featuregate.Feature = "Graduated"
+	// introduced is alpha from 1.2 on.
+	introduced featuregate.Feature = "Introduced"
+)
+
+type object struct {
+	Graduated  string
+	Introduced *int32
+	List       []string
+}
+
+var fields = []Field[*object]{
+	New(graduated, func(o *object) *string { return &o.Graduated }),
+	New(introduced, func(o *object) **int32 { return &o.Introduced }),
+	New(introduced, func(o *object) *[]string { return &o.List }),
+}
+
+// newFeatureGate returns a feature gate of a binary of version 1.2 emulating
+// the given version.
+func newFeatureGate(t *testing.T, emulationVersion string) featuregate.MutableVersionedFeatureGate {
+	featureGate := featuregate.NewVersionedFeatureGate(version.MustParse("1.2"))
+	require.NoError(t, featureGate.AddVersioned(map[featuregate.Feature]featuregate.VersionedSpecs{
+		graduated: {
+			{Version: version.MustParse("1.2"), Default: true, PreRelease: featuregate.GA, LockToDefault: true},
+			{Version: version.MustParse("1.1"), Default: true, PreRelease: featuregate.Beta},
+			{Version: version.MustParse("1.0"), Default: false, PreRelease: featuregate.Alpha},
+		},
+		introduced: {
+			{Version: version.MustParse("1.2"), Default: false, PreRelease: featuregate.Alpha},
+		},
+	}))
+	require.NoError(t, featureGate.SetEmulationVersion(version.MustParse(emulationVersion)))
+	return featureGate
+}
+
+func TestDropOnCreate(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		emulationVersion string
+		enabled          map[string]bool
+		expected         object
+	}{
+		{
+			desc:             "alpha fields are dropped",
+			emulationVersion: "1.0",
+			expected:         object{},
+		},
+		{
+			desc:             "beta fields are kept",
+			emulationVersion: "1.1",
+			expected:         object{Graduated: "set"},
+		},
+		{
+			desc:             "GA fields are kept",
+			emulationVersion: "1.2",
+			expected:         object{Graduated: "set"},
+		},
+		{
+			desc:             "enabled alpha fields are kept",
+			emulationVersion: "1.2",
+			enabled:          map[string]bool{string(introduced): true},
+			expected:         object{Graduated: "set", Introduced: ptr.To[int32](1), List: []string{"set"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			featureGate := newFeatureGate(t, tc.emulationVersion)
+			require.NoError(t, featureGate.SetFromMap(tc.enabled))
+
+			obj := &object{Graduated: "set", Introduced: ptr.To[int32](1), List: []string{"set"}}
+			DropOnCreate(featureGate, obj, fields...)
+			assert.Equal(t, tc.expected, *obj)
+		})
+	}
+}
+
+func TestDropOnUpdate(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		emulationVersion string
+		old              object
+		expected         object
+	}{
+		{
+			desc:             "disabled fields are dropped if not in use",
+			emulationVersion: "1.0",
+			old:              object{},
+			expected:         object{},
+		},
+		{
+			desc:             "disabled fields are kept if in use",
+			emulationVersion: "1.0",
+			old:              object{Graduated: "old", Introduced: ptr.To[int32](2), List: []string{"old"}},
+			expected:         object{Graduated: "new", Introduced: ptr.To[int32](1), List: []string{"new"}},
+		},
+		{
+			desc:             "beta fields are kept",
+			emulationVersion: "1.1",
+			old:              object{},
+			expected:         object{Graduated: "new"},
+		},
+		{
+			desc:             "GA fields are kept",
+			emulationVersion: "1.2",
+			old:              object{},
+			expected:         object{Graduated: "new"},
+		},
+		{
+			desc:             "alpha fields are kept if in use",
+			emulationVersion: "1.2",
+			old:              object{Introduced: ptr.To[int32](2)},
+			expected:         object{Graduated: "new", Introduced: ptr.To[int32](1)},
+		},
+		{
+			desc:             "empty lists are not in use",
+			emulationVersion: "1.2",
+			old:              object{List: []string{}},
+			expected:         object{Graduated: "new"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			featureGate := newFeatureGate(t, tc.emulationVersion)
+
+			obj := &object{Graduated: "new", Introduced: ptr.To[int32](1), List: []string{"new"}}
+			old := tc.old
+			DropOnUpdate(featureGate, obj, &old, fields...)
+			assert.Equal(t, tc.expected, *obj)
+			assert.Equal(t, tc.old, old, "the old object must not be modified")
+		})
+	}
+}
+
+func TestNilFeatureGate(t *testing.T) {
+	obj := &object{Graduated: "set", Introduced: ptr.To[int32](1)}
+	DropOnCreate(nil, obj, fields...)
+	DropOnUpdate(nil, obj, &object{}, fields...)
+	assert.Equal(t, object{Graduated: "set", Introduced: ptr.To[int32](1)}, *obj)
+}
@@ -21,13 +21,18 @@ import (
 	"k8s.io/apiserver/pkg/registry/generic"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	wardleinformers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions/wardle/v1alpha1"
 	"k8s.io/sample-apiserver/pkg/registry"
 )
 
 // NewREST returns a RESTStorage object that will work against API services.
-func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter) (*registry.REST, error) {
-	strategy := NewStrategy(scheme)
+// If flunders is not nil, dry-run creates and updates warn about the existing
+// Flunders the Fischer would ban. If featureGate is not nil, the fields of
+// disabled features are dropped on write.
+func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter, flunders wardleinformers.FlunderInformer, featureGate featuregate.FeatureGate) (*registry.REST, error) {
+	strategy := NewStrategy(scheme).WithFeatureGate(featureGate)
 
 	store := &genericregistry.Store{
 		NewFunc:                   func() runtime.Object { return &wardle.Fischer{} },
@@ -43,6 +48,11 @@ func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter) (*reg
 		// TODO: define table converter that exposes more than name/creation timestamp
 		TableConvertor: rest.NewDefaultTableConvertor(wardle.Resource("fischers")),
 	}
+	if flunders != nil {
+		w := whatIf{scheme: scheme, flunders: flunders.Lister(), hasSynced: flunders.Informer().HasSynced}
+		store.BeginCreate = w.BeginCreate
+		store.BeginUpdate = w.BeginUpdate
+	}
 	options := &generic.StoreOptions{RESTOptions: optsGetter, AttrFunc: GetAttrs}
 	if err := store.CompleteWithOptions(options); err != nil {
 		return nil, err
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fischer
+
+import (
+	"bytes"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+)
+
+var disallowedFlundersPath = fieldpath.MakePathOrDie("disallowedFlunders")
+
+// upgradeManagedFields moves the managed fields entries of v1alpha1, in which
+// disallowedFlunders is an atomic list, to v1beta1, in which it is a set. A
+// manager which owns the atomic list owns each of its current items instead.
+// Otherwise a manager which applied the list in v1alpha1 would conflict with
+// every manager which applies single items in v1beta1, until it applied again
+// in v1beta1 itself. The entries are left untouched if any of them cannot be
+// upgraded.
+func upgradeManagedFields(fischer *wardle.Fischer) error {
+	if len(fischer.ManagedFields) == 0 {
+		return nil
+	}
+	upgraded := make([]metav1.ManagedFieldsEntry, len(fischer.ManagedFields))
+	for i := range fischer.ManagedFields {
+		entry := &upgraded[i]
+		fischer.ManagedFields[i].DeepCopyInto(entry)
+		if entry.APIVersion != v1alpha1.SchemeGroupVersion.String() || entry.FieldsV1 == nil {
+			continue
+		}
+
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return fmt.Errorf("failed to decode managed fields of %q: %w", entry.Manager, err)
+		}
+		if set.Has(disallowedFlundersPath) {
+			set = set.Difference(fieldpath.NewSet(disallowedFlundersPath))
+			for _, name := range fischer.DisallowedFlunders {
+				set.Insert(fieldpath.MakePathOrDie("disallowedFlunders", value.NewValueInterface(name)))
+			}
+		}
+		raw, err := set.ToJSON()
+		if err != nil {
+			return fmt.Errorf("failed to encode managed fields of %q: %w", entry.Manager, err)
+		}
+
+		entry.APIVersion = v1beta1.SchemeGroupVersion.String()
+		entry.FieldsV1 = &metav1.FieldsV1{Raw: raw}
+	}
+	fischer.ManagedFields = upgraded
+	return nil
+}
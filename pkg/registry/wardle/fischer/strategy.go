@@ -20,20 +20,41 @@ import (
 	"context"
 	"fmt"
 
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/registry/generic"
 	"k8s.io/apiserver/pkg/storage"
 	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/component-base/featuregate"
 
 	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/validation"
+	"k8s.io/sample-apiserver/pkg/features"
+	"k8s.io/sample-apiserver/pkg/registry/fieldgate"
 )
 
 // NewStrategy creates and returns a fischerStrategy instance
 func NewStrategy(typer runtime.ObjectTyper) fischerStrategy {
-	return fischerStrategy{typer, names.SimpleNameGenerator}
+	return fischerStrategy{typer, names.SimpleNameGenerator, nil}
+}
+
+// WithFeatureGate returns a copy of the strategy which drops the fields of the
+// features disabled by featureGate from written Fischers.
+func (s fischerStrategy) WithFeatureGate(featureGate featuregate.FeatureGate) fischerStrategy {
+	s.featureGate = featureGate
+	return s
+}
+
+// gatedFields are the fields of Fischers which are dropped on write while
+// their feature is disabled.
+var gatedFields = []fieldgate.Field[*wardle.Fischer]{
+	fieldgate.New(features.FischerNearMissDistance, func(f *wardle.Fischer) **int32 { return &f.NearMissDistance }),
+	fieldgate.New(features.FischerFlunderDefaults, func(f *wardle.Fischer) *[]wardle.FlunderDefaults { return &f.FlunderDefaults }),
 }
 
 // GetAttrs returns labels.Set, fields.Set, and error in case the given runtime.Object is not a Fischer
@@ -63,20 +84,50 @@ func SelectableFields(obj *wardle.Fischer) fields.Set {
 type fischerStrategy struct {
 	runtime.ObjectTyper
 	names.NameGenerator
+	featureGate featuregate.FeatureGate
 }
 
 func (fischerStrategy) NamespaceScoped() bool {
 	return false
 }
 
-func (fischerStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+// PrepareForCreate drops the fields of disabled features and sets the
+// generation of a new Fischer to 1.
+func (s fischerStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	fischer := obj.(*wardle.Fischer)
+	fieldgate.DropOnCreate(s.featureGate, fischer, gatedFields...)
+	fischer.Generation = 1
+	if err := upgradeManagedFields(fischer); err != nil {
+		utilruntime.HandleError(err)
+	}
+}
+
+// PrepareForUpdate drops the fields of disabled features which the Fischer
+// did not use before, and bumps the generation if anything but the metadata
+// changes.
+func (s fischerStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	newFischer := obj.(*wardle.Fischer)
+	oldFischer := old.(*wardle.Fischer)
+	fieldgate.DropOnUpdate(s.featureGate, newFischer, oldFischer, gatedFields...)
+	if specChanged(newFischer, oldFischer) {
+		newFischer.Generation = oldFischer.Generation + 1
+	}
+	if err := upgradeManagedFields(newFischer); err != nil {
+		utilruntime.HandleError(err)
+	}
 }
 
-func (fischerStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+// specChanged returns whether the fields of the Fischers outside of the
+// metadata differ.
+func specChanged(newFischer, oldFischer *wardle.Fischer) bool {
+	newSpec, oldSpec := newFischer.DeepCopy(), oldFischer.DeepCopy()
+	newSpec.ObjectMeta, oldSpec.ObjectMeta = metav1.ObjectMeta{}, metav1.ObjectMeta{}
+	return !apiequality.Semantic.DeepEqual(newSpec, oldSpec)
 }
 
 func (fischerStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
-	return field.ErrorList{}
+	fischer := obj.(*wardle.Fischer)
+	return validation.ValidateFischer(fischer)
 }
 
 // WarningsOnCreate returns warnings for the creation of the given object.
@@ -94,7 +145,7 @@ func (fischerStrategy) Canonicalize(obj runtime.Object) {
 }
 
 func (fischerStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
-	return field.ErrorList{}
+	return validation.ValidateFischerUpdate(obj.(*wardle.Fischer), old.(*wardle.Fischer))
 }
 
 // WarningsOnUpdate returns warnings for the given update.
@@ -0,0 +1,100 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fischer
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/util/dryrun"
+	"k8s.io/apiserver/pkg/warning"
+
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/banning"
+	listers "k8s.io/sample-apiserver/pkg/generated/listers/wardle/v1alpha1"
+)
+
+// whatIf warns dry-run creates and updates of Fischers about the existing
+// Flunders the new entries would ban, and about the Flunders referencing them.
+type whatIf struct {
+	scheme    *runtime.Scheme
+	flunders  listers.FlunderLister
+	hasSynced func() bool
+}
+
+func (w whatIf) BeginCreate(ctx context.Context, obj runtime.Object, options *metav1.CreateOptions) (genericregistry.FinishFunc, error) {
+	if dryrun.IsDryRun(options.DryRun) {
+		w.warn(ctx, nil, obj.(*wardle.Fischer))
+	}
+	return finishNothing, nil
+}
+
+func (w whatIf) BeginUpdate(ctx context.Context, obj, old runtime.Object, options *metav1.UpdateOptions) (genericregistry.FinishFunc, error) {
+	if dryrun.IsDryRun(options.DryRun) {
+		w.warn(ctx, old.(*wardle.Fischer), obj.(*wardle.Fischer))
+	}
+	return finishNothing, nil
+}
+
+func finishNothing(context.Context, bool) {}
+
+func (w whatIf) warn(ctx context.Context, old, updated *wardle.Fischer) {
+	// before the informer has synced the lister would return no flunders,
+	// and with them no warnings
+	if !w.hasSynced() {
+		warning.AddWarning(ctx, "", fmt.Sprintf("the flunders Fischer %q would ban are not yet known, the preview is unavailable", updated.Name))
+		return
+	}
+	impact, err := w.impact(old, updated)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to compute the impact of fischer %q: %w", updated.Name, err))
+		return
+	}
+	for _, flunder := range impact.Banned {
+		warning.AddWarning(ctx, "", fmt.Sprintf("Fischer %q would ban the existing flunder %s/%s", updated.Name, flunder.Namespace, flunder.Name))
+	}
+	for _, reference := range impact.Referencing {
+		warning.AddWarning(ctx, "", fmt.Sprintf("flunder %s/%s references flunder %s/%s, which Fischer %q would ban",
+			reference.Flunder.Namespace, reference.Flunder.Name, reference.Target.Namespace, reference.Target.Name, updated.Name))
+	}
+}
+
+func (w whatIf) impact(old, updated *wardle.Fischer) (banning.Impact, error) {
+	flunders, err := w.flunders.List(labels.Everything())
+	if err != nil {
+		return banning.Impact{}, err
+	}
+
+	var versionedOld *v1alpha1.Fischer
+	if old != nil {
+		versionedOld = &v1alpha1.Fischer{}
+		if err := w.scheme.Convert(old, versionedOld, nil); err != nil {
+			return banning.Impact{}, err
+		}
+	}
+	versionedUpdated := &v1alpha1.Fischer{}
+	if err := w.scheme.Convert(updated, versionedUpdated, nil); err != nil {
+		return banning.Impact{}, err
+	}
+	return banning.WhatIf(versionedOld, versionedUpdated, flunders), nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fischer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/warning"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/install"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/fake"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+)
+
+type recorder []string
+
+func (r *recorder) AddWarning(_, text string) {
+	*r = append(*r, text)
+}
+
+func TestWhatIfWarnings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	fischer := &wardle.Fischer{
+		ObjectMeta:         metav1.ObjectMeta{Name: "fischer"},
+		DisallowedFlunders: []string{"target"},
+	}
+	options := &metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}
+
+	client := fake.NewSimpleClientset(&v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "target"}})
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	flunders := informerFactory.Wardle().V1alpha1().Flunders()
+	w := whatIf{scheme: scheme, flunders: flunders.Lister(), hasSynced: flunders.Informer().HasSynced}
+
+	// the informer has not been started, so nothing is known about the
+	// existing flunders
+	var warnings recorder
+	_, err := w.BeginCreate(warning.WithWarningRecorder(context.Background(), &warnings), fischer, options)
+	require.NoError(t, err)
+	assert.Equal(t, recorder{`the flunders Fischer "fischer" would ban are not yet known, the preview is unavailable`}, warnings)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	informerFactory.Start(stop)
+	informerFactory.WaitForCacheSync(stop)
+
+	warnings = nil
+	_, err = w.BeginCreate(warning.WithWarningRecorder(context.Background(), &warnings), fischer, options)
+	require.NoError(t, err)
+	assert.Equal(t, recorder{`Fischer "fischer" would ban the existing flunder ns/target`}, warnings)
+}
@@ -21,14 +21,18 @@ import (
 	"k8s.io/apiserver/pkg/registry/generic"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	listers "k8s.io/sample-apiserver/pkg/generated/listers/wardle/v1alpha1"
 	"k8s.io/sample-apiserver/pkg/registry"
 )
 
 // NewREST returns a RESTStorage object that will work against API services.
-func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter) (*registry.REST, error) {
-	strategy := NewStrategy(scheme)
-
+// If fischers is not nil, it warns about the creation of Flunders with names
+// close to the entries of Fischers. Flunders with the OwnedByReference
+// ownership policy are owned by the Flunder they reference. If featureGate is
+// not nil, the fields of disabled features are dropped on write.
+func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter, fischers listers.FischerLister, featureGate featuregate.FeatureGate) (*registry.REST, error) {
 	store := &genericregistry.Store{
 		NewFunc:                   func() runtime.Object { return &wardle.Flunder{} },
 		NewListFunc:               func() runtime.Object { return &wardle.FlunderList{} },
@@ -36,13 +40,15 @@ func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter) (*reg
 		DefaultQualifiedResource:  wardle.Resource("flunders"),
 		SingularQualifiedResource: wardle.Resource("flunder"),
 
-		CreateStrategy: strategy,
-		UpdateStrategy: strategy,
-		DeleteStrategy: strategy,
-
 		// TODO: define table converter that exposes more than name/creation timestamp
 		TableConvertor: rest.NewDefaultTableConvertor(wardle.Resource("flunders")),
 	}
+	// the strategy gets the owners of new flunders from the store itself
+	strategy := NewStrategy(scheme, fischers).WithOwners(store).WithFeatureGate(featureGate)
+	store.CreateStrategy = strategy
+	store.UpdateStrategy = strategy
+	store.DeleteStrategy = strategy
+
 	options := &generic.StoreOptions{RESTOptions: optsGetter, AttrFunc: GetAttrs}
 	if err := store.CompleteWithOptions(options); err != nil {
 		return nil, err
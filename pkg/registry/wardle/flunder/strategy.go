@@ -20,21 +20,58 @@ import (
 	"context"
 	"fmt"
 
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/apiserver/pkg/storage"
 	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/sample-apiserver/pkg/apis/wardle/validation"
+	"k8s.io/utils/ptr"
 
 	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/banning"
+	"k8s.io/sample-apiserver/pkg/features"
+	listers "k8s.io/sample-apiserver/pkg/generated/listers/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/registry/fieldgate"
 )
 
-// NewStrategy creates and returns a flunderStrategy instance
-func NewStrategy(typer runtime.ObjectTyper) flunderStrategy {
-	return flunderStrategy{typer, names.SimpleNameGenerator}
+// NewStrategy creates and returns a flunderStrategy instance. If fischers is
+// not nil, the names of new Flunders are checked for near misses of the
+// entries of the Fischers.
+func NewStrategy(typer runtime.ObjectTyper, fischers listers.FischerLister) flunderStrategy {
+	return flunderStrategy{typer, names.SimpleNameGenerator, fischers, nil, nil}
+}
+
+// WithOwners returns a copy of the strategy which gets referenced Flunders
+// from flunders, to make them the owners of new Flunders with the
+// OwnedByReference ownership policy.
+func (s flunderStrategy) WithOwners(flunders rest.Getter) flunderStrategy {
+	s.flunders = flunders
+	return s
+}
+
+// WithFeatureGate returns a copy of the strategy which drops the fields of the
+// features disabled by featureGate from written Flunders.
+func (s flunderStrategy) WithFeatureGate(featureGate featuregate.FeatureGate) flunderStrategy {
+	s.featureGate = featureGate
+	return s
+}
+
+// gatedFields are the fields of Flunders which are dropped on write while
+// their feature is disabled.
+var gatedFields = []fieldgate.Field[*wardle.Flunder]{
+	fieldgate.New(features.FlunderOwnershipPolicy, func(f *wardle.Flunder) *wardle.OwnershipPolicy { return &f.Spec.OwnershipPolicy }),
 }
 
 // GetAttrs returns labels.Set, fields.Set, and error in case the given runtime.Object is not a Flunder
@@ -64,25 +101,130 @@ func SelectableFields(obj *wardle.Flunder) fields.Set {
 type flunderStrategy struct {
 	runtime.ObjectTyper
 	names.NameGenerator
+	fischers    listers.FischerLister
+	flunders    rest.Getter
+	featureGate featuregate.FeatureGate
 }
 
 func (flunderStrategy) NamespaceScoped() bool {
 	return true
 }
 
-func (flunderStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+// PrepareForCreate drops the fields of disabled features, sets the
+// generation of a new Flunder to 1 and makes the referenced Flunder the owner
+// of a Flunder with the OwnedByReference ownership policy. Validate rejects
+// the Flunder if the referenced Flunder cannot be found.
+func (s flunderStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	flunder := obj.(*wardle.Flunder)
+	fieldgate.DropOnCreate(s.featureGate, flunder, gatedFields...)
+	flunder.Generation = 1
+
+	if s.flunders == nil || flunder.Spec.OwnershipPolicy != wardle.OwnedByReferenceOwnershipPolicy ||
+		flunder.Spec.ReferenceType != wardle.FlunderReferenceType || len(flunder.Spec.FlunderReference) == 0 {
+		return
+	}
+
+	// Validate reports a missing owner
+	owner, err := s.getOwner(ctx, flunder.Spec.FlunderReference)
+	if err != nil {
+		return
+	}
+	// owner references supplied by the client are replaced, they might carry
+	// the uid of another Flunder
+	refs := make([]metav1.OwnerReference, 0, len(flunder.OwnerReferences)+1)
+	for _, ref := range flunder.OwnerReferences {
+		if !isFlunderReference(ref, owner.GetName()) {
+			refs = append(refs, ref)
+		}
+	}
+	flunder.OwnerReferences = append(refs, metav1.OwnerReference{
+		APIVersion:         v1alpha1.SchemeGroupVersion.String(),
+		Kind:               "Flunder",
+		Name:               owner.GetName(),
+		UID:                owner.GetUID(),
+		BlockOwnerDeletion: ptr.To(true),
+	})
+}
+
+// getOwner returns the metadata of the named Flunder.
+func (s flunderStrategy) getOwner(ctx context.Context, name string) (metav1.Object, error) {
+	owner, err := s.flunders.Get(ctx, name, &metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return meta.Accessor(owner)
+}
+
+// isFlunderReference returns whether ref is an owner reference to the named
+// Flunder.
+func isFlunderReference(ref metav1.OwnerReference, name string) bool {
+	return ref.Kind == "Flunder" && ref.Name == name && schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).Group == wardle.GroupName
+}
+
+// validateOwnerReference checks that the Flunder referenced by a Flunder with
+// the OwnedByReference ownership policy exists and is its owner.
+func (s flunderStrategy) validateOwnerReference(ctx context.Context, flunder *wardle.Flunder) field.ErrorList {
+	fldPath := field.NewPath("spec", "flunderReference")
+	owner, err := s.getOwner(ctx, flunder.Spec.FlunderReference)
+	if apierrors.IsNotFound(err) {
+		return field.ErrorList{field.NotFound(fldPath, flunder.Spec.FlunderReference)}
+	}
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("failed to get flunder %q: %w", flunder.Spec.FlunderReference, err))}
+	}
+	for _, ref := range flunder.OwnerReferences {
+		if isFlunderReference(ref, owner.GetName()) && ref.UID == owner.GetUID() {
+			return nil
+		}
+	}
+	return field.ErrorList{field.Invalid(field.NewPath("metadata", "ownerReferences"), flunder.OwnerReferences,
+		fmt.Sprintf("must reference flunder %q with uid %q", owner.GetName(), owner.GetUID()))}
 }
 
-func (flunderStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+// PrepareForUpdate drops the fields of disabled features which the Flunder
+// did not use before, and bumps the generation if the spec changes. Changes
+// of the metadata or the status keep it.
+func (s flunderStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	newFlunder := obj.(*wardle.Flunder)
+	oldFlunder := old.(*wardle.Flunder)
+	fieldgate.DropOnUpdate(s.featureGate, newFlunder, oldFlunder, gatedFields...)
+	if !apiequality.Semantic.DeepEqual(newFlunder.Spec, oldFlunder.Spec) {
+		newFlunder.Generation = oldFlunder.Generation + 1
+	}
 }
 
-func (flunderStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+func (s flunderStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
 	flunder := obj.(*wardle.Flunder)
-	return validation.ValidateFlunder(flunder)
+	allErrs := validation.ValidateFlunder(flunder)
+	if s.flunders != nil && len(allErrs) == 0 && flunder.Spec.OwnershipPolicy == wardle.OwnedByReferenceOwnershipPolicy {
+		allErrs = append(allErrs, s.validateOwnerReference(ctx, flunder)...)
+	}
+	return allErrs
 }
 
-// WarningsOnCreate returns warnings for the creation of the given object.
-func (flunderStrategy) WarningsOnCreate(ctx context.Context, obj runtime.Object) []string { return nil }
+// WarningsOnCreate returns warnings for the creation of the given object. It
+// warns about names which are close to a name disallowed by a Fischer.
+func (s flunderStrategy) WarningsOnCreate(ctx context.Context, obj runtime.Object) []string {
+	if s.fischers == nil {
+		return nil
+	}
+	flunder := obj.(*wardle.Flunder)
+	fischers, err := s.fischers.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list fischers: %w", err))
+		return nil
+	}
+
+	var warnings []string
+	for _, nearMiss := range banning.NearMisses(fischers, banning.Attributes{Name: flunder.Name, Namespace: flunder.Namespace}) {
+		if nearMiss.Distance == 0 {
+			warnings = append(warnings, fmt.Sprintf("flunder name %q differs only in case from %q, which Fischer %q disallows", flunder.Name, nearMiss.Entry, nearMiss.Fischer))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("flunder name %q is close to %q, which Fischer %q disallows", flunder.Name, nearMiss.Entry, nearMiss.Fischer))
+		}
+	}
+	return warnings
+}
 
 func (flunderStrategy) AllowCreateOnUpdate() bool {
 	return false
@@ -96,7 +238,7 @@ func (flunderStrategy) Canonicalize(obj runtime.Object) {
 }
 
 func (flunderStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
-	return field.ErrorList{}
+	return validation.ValidateFlunderUpdate(obj.(*wardle.Flunder), old.(*wardle.Flunder))
 }
 
 // WarningsOnUpdate returns warnings for the given update.
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flunderbanreview
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/warning"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/validation"
+	"k8s.io/sample-apiserver/pkg/banning"
+	wardleinformers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions/wardle/v1alpha1"
+	listers "k8s.io/sample-apiserver/pkg/generated/listers/wardle/v1alpha1"
+)
+
+// REST implements a RESTStorage for FlunderBanReviews. Reviews are evaluated
+// against the Fischers and FlunderPolicies of the same informers the BanFlunder
+// plugin uses.
+type REST struct {
+	// banFlunderEnabled is whether the BanFlunder plugin runs. Nothing is
+	// banned if it does not.
+	banFlunderEnabled  bool
+	lister             listers.FischerLister
+	policyLister       listers.FlunderPolicyLister
+	hasSynced          cache.InformerSynced
+	policiesHaveSynced cache.InformerSynced
+}
+
+var _ rest.Creater = &REST{}
+var _ rest.Scoper = &REST{}
+var _ rest.SingularNameProvider = &REST{}
+var _ rest.Storage = &REST{}
+
+// NewREST returns a RESTStorage object that evaluates FlunderBanReviews.
+// banFlunderEnabled is whether the BanFlunder admission plugin runs.
+func NewREST(fischers wardleinformers.FischerInformer, policies wardleinformers.FlunderPolicyInformer, banFlunderEnabled bool) *REST {
+	return &REST{
+		banFlunderEnabled:  banFlunderEnabled,
+		lister:             fischers.Lister(),
+		policyLister:       policies.Lister(),
+		hasSynced:          fischers.Informer().HasSynced,
+		policiesHaveSynced: policies.Informer().HasSynced,
+	}
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+func (r *REST) New() runtime.Object {
+	return &wardle.FlunderBanReview{}
+}
+
+// Destroy cleans up resources on shutdown.
+func (r *REST) Destroy() {
+	// Given no underlying store, we don't destroy anything
+	// here explicitly.
+}
+
+func (r *REST) GetSingularName() string {
+	return "flunderbanreview"
+}
+
+// Create evaluates the review and returns it with its status filled in.
+// Nothing is persisted.
+func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	review, ok := obj.(*wardle.FlunderBanReview)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a FlunderBanReview: %#v", obj))
+	}
+	if errs := validation.ValidateFlunderBanReview(review); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(wardle.Kind("FlunderBanReview"), "", errs)
+	}
+
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	if !r.banFlunderEnabled {
+		warning.AddWarning(ctx, "", "the BanFlunder admission plugin is disabled, no flunder is banned")
+		review.Status = wardle.FlunderBanReviewStatus{Banned: false}
+		return review, nil
+	}
+
+	if !r.hasSynced() || !r.policiesHaveSynced() {
+		return nil, apierrors.NewServiceUnavailable("not yet ready to handle request")
+	}
+	fischers, err := r.lister.List(labels.Everything())
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	var policies []*v1alpha1.FlunderPolicy
+	if len(review.Spec.Namespace) != 0 {
+		policies, err = r.policyLister.FlunderPolicies(review.Spec.Namespace).List(labels.Everything())
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+	}
+
+	bans := banning.Bans(fischers, policies, banning.Attributes{
+		Name:      review.Spec.Name,
+		Namespace: review.Spec.Namespace,
+	})
+	review.Status = wardle.FlunderBanReviewStatus{Banned: len(bans) > 0}
+	for _, ban := range bans {
+		review.Status.Bans = append(review.Status.Bans, wardle.FlunderBan{Fischer: ban.Fischer, FlunderPolicy: ban.FlunderPolicy, Entry: ban.Entry})
+	}
+	return review, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flunderpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/validation"
+
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+)
+
+// NewStrategy creates and returns a flunderPolicyStrategy instance
+func NewStrategy(typer runtime.ObjectTyper) flunderPolicyStrategy {
+	return flunderPolicyStrategy{typer, names.SimpleNameGenerator}
+}
+
+// GetAttrs returns labels.Set, fields.Set, and error in case the given runtime.Object is not a FlunderPolicy
+func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, error) {
+	policy, ok := obj.(*wardle.FlunderPolicy)
+	if !ok {
+		return nil, nil, fmt.Errorf("given object is not a FlunderPolicy")
+	}
+	return labels.Set(policy.ObjectMeta.Labels), SelectableFields(policy), nil
+}
+
+// MatchFlunderPolicy is the filter used by the generic etcd backend to watch events
+// from etcd to clients of the apiserver only interested in specific labels/fields.
+func MatchFlunderPolicy(label labels.Selector, field fields.Selector) storage.SelectionPredicate {
+	return storage.SelectionPredicate{
+		Label:    label,
+		Field:    field,
+		GetAttrs: GetAttrs,
+	}
+}
+
+// SelectableFields returns a field set that represents the object.
+func SelectableFields(obj *wardle.FlunderPolicy) fields.Set {
+	return generic.ObjectMetaFieldsSet(&obj.ObjectMeta, true)
+}
+
+type flunderPolicyStrategy struct {
+	runtime.ObjectTyper
+	names.NameGenerator
+}
+
+func (flunderPolicyStrategy) NamespaceScoped() bool {
+	return true
+}
+
+func (flunderPolicyStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+}
+
+func (flunderPolicyStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+}
+
+func (flunderPolicyStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+	return validation.ValidateFlunderPolicy(obj.(*wardle.FlunderPolicy))
+}
+
+// WarningsOnCreate returns warnings for the creation of the given object.
+func (flunderPolicyStrategy) WarningsOnCreate(ctx context.Context, obj runtime.Object) []string {
+	return nil
+}
+
+func (flunderPolicyStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (flunderPolicyStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+func (flunderPolicyStrategy) Canonicalize(obj runtime.Object) {
+}
+
+func (flunderPolicyStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	return validation.ValidateFlunderPolicy(obj.(*wardle.FlunderPolicy))
+}
+
+// WarningsOnUpdate returns warnings for the given update.
+func (flunderPolicyStrategy) WarningsOnUpdate(ctx context.Context, obj, old runtime.Object) []string {
+	return nil
+}
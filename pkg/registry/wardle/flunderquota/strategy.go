@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flunderquota
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/validation"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+)
+
+// NewStrategy creates and returns a flunderQuotaStrategy instance
+func NewStrategy(typer runtime.ObjectTyper) flunderQuotaStrategy {
+	return flunderQuotaStrategy{typer, names.SimpleNameGenerator}
+}
+
+// NewStatusStrategy creates and returns a flunderQuotaStatusStrategy instance
+func NewStatusStrategy(typer runtime.ObjectTyper) flunderQuotaStatusStrategy {
+	return flunderQuotaStatusStrategy{NewStrategy(typer)}
+}
+
+// GetAttrs returns labels.Set, fields.Set, and error in case the given runtime.Object is not a FlunderQuota
+func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, error) {
+	quota, ok := obj.(*wardle.FlunderQuota)
+	if !ok {
+		return nil, nil, fmt.Errorf("given object is not a FlunderQuota")
+	}
+	return labels.Set(quota.ObjectMeta.Labels), SelectableFields(quota), nil
+}
+
+// MatchFlunderQuota is the filter used by the generic etcd backend to watch events
+// from etcd to clients of the apiserver only interested in specific labels/fields.
+func MatchFlunderQuota(label labels.Selector, field fields.Selector) storage.SelectionPredicate {
+	return storage.SelectionPredicate{
+		Label:    label,
+		Field:    field,
+		GetAttrs: GetAttrs,
+	}
+}
+
+// SelectableFields returns a field set that represents the object.
+func SelectableFields(obj *wardle.FlunderQuota) fields.Set {
+	return generic.ObjectMetaFieldsSet(&obj.ObjectMeta, true)
+}
+
+type flunderQuotaStrategy struct {
+	runtime.ObjectTyper
+	names.NameGenerator
+}
+
+func (flunderQuotaStrategy) NamespaceScoped() bool {
+	return true
+}
+
+// GetResetFields returns the fields which are reset by the strategy: the status
+// is only written through the status subresource.
+func (flunderQuotaStrategy) GetResetFields() map[fieldpath.APIVersion]*fieldpath.Set {
+	return map[fieldpath.APIVersion]*fieldpath.Set{
+		"wardle.example.com/v1alpha1": fieldpath.NewSet(
+			fieldpath.MakePathOrDie("status"),
+		),
+	}
+}
+
+func (flunderQuotaStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	quota := obj.(*wardle.FlunderQuota)
+	quota.Status = wardle.FlunderQuotaStatus{}
+}
+
+func (flunderQuotaStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	newQuota := obj.(*wardle.FlunderQuota)
+	oldQuota := old.(*wardle.FlunderQuota)
+	newQuota.Status = oldQuota.Status
+}
+
+func (flunderQuotaStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+	return validation.ValidateFlunderQuota(obj.(*wardle.FlunderQuota))
+}
+
+// WarningsOnCreate returns warnings for the creation of the given object.
+func (flunderQuotaStrategy) WarningsOnCreate(ctx context.Context, obj runtime.Object) []string {
+	return nil
+}
+
+func (flunderQuotaStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (flunderQuotaStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+func (flunderQuotaStrategy) Canonicalize(obj runtime.Object) {
+}
+
+func (flunderQuotaStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	return validation.ValidateFlunderQuota(obj.(*wardle.FlunderQuota))
+}
+
+// WarningsOnUpdate returns warnings for the given update.
+func (flunderQuotaStrategy) WarningsOnUpdate(ctx context.Context, obj, old runtime.Object) []string {
+	return nil
+}
+
+type flunderQuotaStatusStrategy struct {
+	flunderQuotaStrategy
+}
+
+// GetResetFields returns the fields which are reset by the strategy: the spec
+// is not written through the status subresource.
+func (flunderQuotaStatusStrategy) GetResetFields() map[fieldpath.APIVersion]*fieldpath.Set {
+	return map[fieldpath.APIVersion]*fieldpath.Set{
+		"wardle.example.com/v1alpha1": fieldpath.NewSet(
+			fieldpath.MakePathOrDie("spec"),
+		),
+	}
+}
+
+func (flunderQuotaStatusStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	newQuota := obj.(*wardle.FlunderQuota)
+	oldQuota := old.(*wardle.FlunderQuota)
+	newQuota.Spec = oldQuota.Spec
+}
+
+func (flunderQuotaStatusStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	return validation.ValidateFlunderQuotaStatus(obj.(*wardle.FlunderQuota))
+}
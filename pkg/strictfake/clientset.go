@@ -0,0 +1,411 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package strictfake provides a fake wardle clientset which rejects the objects
+// the wardle server would reject. Creates, updates and patches run the
+// registry strategies and the chosen admission plugins before they reach the
+// object tracker, so tests see the same Invalid and Forbidden errors as
+// clients of the server.
+package strictfake
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/testing"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/banflunder"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/celvalidation"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/flunderdefaults"
+	"k8s.io/sample-apiserver/pkg/admission/plugin/flunderquota"
+	"k8s.io/sample-apiserver/pkg/admission/wardleinitializer"
+	"k8s.io/sample-apiserver/pkg/apis/wardle"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apiserver"
+	"k8s.io/sample-apiserver/pkg/generated/clientset/versioned/fake"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+	fischerstorage "k8s.io/sample-apiserver/pkg/registry/wardle/fischer"
+	flunderstorage "k8s.io/sample-apiserver/pkg/registry/wardle/flunder"
+	flunderpolicystorage "k8s.io/sample-apiserver/pkg/registry/wardle/flunderpolicy"
+	flunderquotastorage "k8s.io/sample-apiserver/pkg/registry/wardle/flunderquota"
+	"sigs.k8s.io/yaml"
+)
+
+// newStrategies returns the strategies of the stored wardle resources. The
+// Flunder strategy gets the owners of new Flunders from tracker.
+func newStrategies(tracker testing.ObjectTracker) map[schema.GroupResource]rest.RESTCreateUpdateStrategy {
+	return map[schema.GroupResource]rest.RESTCreateUpdateStrategy{
+		wardle.Resource("fischers"):        fischerstorage.NewStrategy(apiserver.Scheme),
+		wardle.Resource("flunders"):        flunderstorage.NewStrategy(apiserver.Scheme, nil).WithOwners(trackerGetter{tracker, v1alpha1.SchemeGroupVersion.WithResource("flunders")}),
+		wardle.Resource("flunderpolicies"): flunderpolicystorage.NewStrategy(apiserver.Scheme),
+		wardle.Resource("flunderquotas"):   flunderquotastorage.NewStrategy(apiserver.Scheme),
+	}
+}
+
+// trackerGetter gets objects of a resource from the tracker, in the namespace
+// of the request.
+type trackerGetter struct {
+	tracker  testing.ObjectTracker
+	resource schema.GroupVersionResource
+}
+
+func (g trackerGetter) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	return g.tracker.Get(g.resource, genericapirequest.NamespaceValue(ctx), name)
+}
+
+// requestUser is the user which makes the requests seen by admission plugins.
+var requestUser = &user.DefaultInfo{Name: "strictfake"}
+
+// NewClientset returns a fake clientset with the given objects, which
+// validates written wardle objects like the wardle server and runs the named
+// admission plugins, e.g. BanFlunder, on them. The given objects are added to
+// the tracker without validation.
+//
+// Like the wardle server, the clientset sets the resourceVersion of written
+// objects and rejects updates of outdated objects with a Conflict error.
+//
+// Admission plugins which need the wardle informers see the content of the
+// tracker at the time of the request, without the delay of a real informer.
+func NewClientset(admissionPlugins []string, objects ...runtime.Object) (*fake.Clientset, error) {
+	return NewClientsetWithConfig(admissionPlugins, nil, objects...)
+}
+
+// NewClientsetWithConfig is like NewClientset, and configures the admission
+// plugins with the configurations in pluginConfig by plugin name, e.g. the
+// rules of CELValidation. A configuration is the YAML of the configuration of
+// the plugin in an admission configuration file.
+func NewClientsetWithConfig(admissionPlugins []string, pluginConfig map[string]string, objects ...runtime.Object) (*fake.Clientset, error) {
+	// the apply configurations have no schema of the wardle types, which the
+	// field managed tracker of fake.NewClientset requires
+	r := &reactor{}
+	seeded := make([]runtime.Object, 0, len(objects))
+	for _, obj := range objects {
+		obj = obj.DeepCopyObject()
+		if objMeta, err := meta.Accessor(obj); err == nil && len(objMeta.GetResourceVersion()) == 0 {
+			objMeta.SetResourceVersion(r.nextResourceVersion())
+		}
+		seeded = append(seeded, obj)
+	}
+	cs := fake.NewSimpleClientset(seeded...)
+	r.tracker = cs.Tracker()
+	r.strategies = newStrategies(r.tracker)
+
+	plugins := admission.NewPlugins()
+	banflunder.Register(plugins)
+	flunderquota.Register(plugins)
+	celvalidation.Register(plugins)
+	flunderdefaults.Register(plugins)
+	factory := newTrackerInformerFactory(informers.NewSharedInformerFactory(cs, 0), cs.Tracker())
+	chain, err := plugins.NewFromPlugins(admissionPlugins, configProvider(pluginConfig), wardleinitializer.New(factory, newTrackerClientset(cs.Tracker())), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r.admission = chain
+	cs.PrependReactor("create", "*", r.create)
+	cs.PrependReactor("update", "*", r.update)
+	cs.PrependReactor("patch", "*", r.patch)
+	cs.PrependReactor("delete", "*", r.delete)
+	return cs, nil
+}
+
+// newTrackerClientset returns a clientset which reads and writes the tracker
+// directly, for the admission plugins. They cannot use the strict clientset,
+// whose reactors they run in: it does not run the next action before the
+// current one has returned.
+func newTrackerClientset(tracker testing.ObjectTracker) *fake.Clientset {
+	cs := &fake.Clientset{}
+	cs.AddReactor("*", "*", testing.ObjectReaction(tracker))
+	return cs
+}
+
+// configProvider provides the configurations of the admission plugins by
+// plugin name.
+type configProvider map[string]string
+
+func (p configProvider) ConfigFor(pluginName string) (io.Reader, error) {
+	config, found := p[pluginName]
+	if !found {
+		return nil, nil
+	}
+	return strings.NewReader(config), nil
+}
+
+// reactor does not need to lock, the fake clientset runs one reactor at a
+// time.
+type reactor struct {
+	tracker         testing.ObjectTracker
+	strategies      map[schema.GroupResource]rest.RESTCreateUpdateStrategy
+	admission       admission.Interface
+	resourceVersion uint64
+}
+
+func (r *reactor) nextResourceVersion() string {
+	r.resourceVersion++
+	return strconv.FormatUint(r.resourceVersion, 10)
+}
+
+func (r *reactor) create(action testing.Action) (bool, runtime.Object, error) {
+	create := action.(testing.CreateActionImpl)
+	strategy, found := r.strategies[create.Resource.GroupResource()]
+	if !found || len(create.Subresource) != 0 {
+		return false, nil, nil
+	}
+
+	ctx := genericapirequest.WithNamespace(context.Background(), create.Namespace)
+	obj, kind, err := toInternal(create.Object)
+	if err != nil {
+		return true, nil, err
+	}
+	attrs := r.attributes(obj, nil, kind, create.Resource, admission.Create, &metav1.CreateOptions{})
+	if err := r.admit(ctx, attrs); err != nil {
+		return true, nil, err
+	}
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return true, nil, err
+	}
+	rest.FillObjectMetaSystemFields(objMeta)
+	if len(objMeta.GetGenerateName()) > 0 && len(objMeta.GetName()) == 0 {
+		objMeta.SetName(strategy.GenerateName(objMeta.GetGenerateName()))
+	}
+	objMeta.SetResourceVersion(r.nextResourceVersion())
+	if err := rest.BeforeCreate(strategy, ctx, obj); err != nil {
+		return true, nil, err
+	}
+	if err := r.validate(ctx, attrs); err != nil {
+		return true, nil, err
+	}
+
+	create.Object, err = fromInternal(obj, kind.GroupVersion())
+	if err != nil {
+		return true, nil, err
+	}
+	return testing.ObjectReaction(r.tracker)(create)
+}
+
+func (r *reactor) update(action testing.Action) (bool, runtime.Object, error) {
+	update := action.(testing.UpdateActionImpl)
+	strategy, found := r.strategies[update.Resource.GroupResource()]
+	if !found || len(update.Subresource) != 0 {
+		return false, nil, nil
+	}
+
+	objMeta, err := meta.Accessor(update.Object)
+	if err != nil {
+		return true, nil, err
+	}
+	current, err := r.tracker.Get(update.Resource, update.Namespace, objMeta.GetName())
+	if err != nil {
+		return true, nil, err
+	}
+	obj, err := r.validateUpdate(update.Resource, update.Namespace, strategy, update.Object, current)
+	if err != nil {
+		return true, nil, err
+	}
+	if currentMeta, err := meta.Accessor(current); err != nil {
+		return true, nil, err
+	} else if len(objMeta.GetResourceVersion()) != 0 && objMeta.GetResourceVersion() != currentMeta.GetResourceVersion() {
+		return true, nil, apierrors.NewConflict(update.Resource.GroupResource(), objMeta.GetName(), fmt.Errorf(registry.OptimisticLockErrorMsg))
+	}
+	if err := r.setNextResourceVersion(obj); err != nil {
+		return true, nil, err
+	}
+
+	update.Object = obj
+	return testing.ObjectReaction(r.tracker)(update)
+}
+
+// patch computes the patched object on a copy of the stored object, and
+// writes it to the tracker only if it is accepted. Server-side apply of a
+// missing object is a create.
+func (r *reactor) patch(action testing.Action) (bool, runtime.Object, error) {
+	patch := action.(testing.PatchActionImpl)
+	strategy, found := r.strategies[patch.Resource.GroupResource()]
+	if !found || len(patch.Subresource) != 0 {
+		return false, nil, nil
+	}
+
+	current, err := r.tracker.Get(patch.Resource, patch.Namespace, patch.Name)
+	if apierrors.IsNotFound(err) && patch.PatchType == types.ApplyPatchType {
+		obj, err := appliedObject(patch)
+		if err != nil {
+			return true, nil, err
+		}
+		return r.create(testing.NewCreateAction(patch.Resource, patch.Namespace, obj))
+	}
+	if err != nil {
+		return true, nil, err
+	}
+	// the tracker of a scratch clientset holds the copy, so that watchers of
+	// the tracker never see a rejected object
+	scratch := fake.NewSimpleClientset(current).Tracker()
+	_, patched, err := testing.ObjectReaction(scratch)(patch)
+	if err != nil {
+		return true, nil, err
+	}
+
+	obj, err := r.validateUpdate(patch.Resource, patch.Namespace, strategy, patched, current)
+	if err != nil {
+		return true, nil, err
+	}
+	if err := r.setNextResourceVersion(obj); err != nil {
+		return true, nil, err
+	}
+	if err := r.tracker.Update(patch.Resource, obj, patch.Namespace); err != nil {
+		return true, nil, err
+	}
+	return true, obj, nil
+}
+
+// appliedObject decodes the apply configuration of an apply patch into the
+// object it creates.
+func appliedObject(patch testing.PatchActionImpl) (runtime.Object, error) {
+	applied := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	if err := yaml.Unmarshal(patch.Patch, &applied.Object); err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	applied.SetName(patch.Name)
+	applied.SetNamespace(patch.Namespace)
+	obj, err := apiserver.Scheme.New(applied.GroupVersionKind())
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(applied.Object, obj); err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	return obj, nil
+}
+
+func (r *reactor) delete(action testing.Action) (bool, runtime.Object, error) {
+	del := action.(testing.DeleteActionImpl)
+	if _, found := r.strategies[del.Resource.GroupResource()]; !found || len(del.Subresource) != 0 {
+		return false, nil, nil
+	}
+
+	current, err := r.tracker.Get(del.Resource, del.Namespace, del.Name)
+	if err != nil {
+		return true, nil, err
+	}
+	old, kind, err := toInternal(current)
+	if err != nil {
+		return true, nil, err
+	}
+	ctx := genericapirequest.WithNamespace(context.Background(), del.Namespace)
+	attrs := r.attributes(nil, old, kind, del.Resource, admission.Delete, &del.DeleteOptions)
+	if err := r.admit(ctx, attrs); err != nil {
+		return true, nil, err
+	}
+	if err := r.validate(ctx, attrs); err != nil {
+		return true, nil, err
+	}
+	return false, nil, nil
+}
+
+// validateUpdate runs admission and the strategy on the update of current to
+// obj, and returns the resulting object in the version of obj.
+func (r *reactor) validateUpdate(resource schema.GroupVersionResource, namespace string, strategy rest.RESTUpdateStrategy, obj, current runtime.Object) (runtime.Object, error) {
+	ctx := genericapirequest.WithNamespace(context.Background(), namespace)
+	internal, kind, err := toInternal(obj)
+	if err != nil {
+		return nil, err
+	}
+	old, _, err := toInternal(current)
+	if err != nil {
+		return nil, err
+	}
+	attrs := r.attributes(internal, old, kind, resource, admission.Update, &metav1.UpdateOptions{})
+	if err := r.admit(ctx, attrs); err != nil {
+		return nil, err
+	}
+	if err := rest.BeforeUpdate(strategy, ctx, internal, old); err != nil {
+		return nil, err
+	}
+	if err := r.validate(ctx, attrs); err != nil {
+		return nil, err
+	}
+	return fromInternal(internal, kind.GroupVersion())
+}
+
+func (r *reactor) setNextResourceVersion(obj runtime.Object) error {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	objMeta.SetResourceVersion(r.nextResourceVersion())
+	return nil
+}
+
+func (r *reactor) attributes(obj, old runtime.Object, kind schema.GroupVersionKind, resource schema.GroupVersionResource, operation admission.Operation, options runtime.Object) admission.Attributes {
+	var namespace, name string
+	for _, o := range []runtime.Object{obj, old} {
+		if objMeta, err := meta.Accessor(o); err == nil {
+			namespace, name = objMeta.GetNamespace(), objMeta.GetName()
+			break
+		}
+	}
+	return admission.NewAttributesRecord(obj, old, kind, namespace, name, resource, "", operation, options, false, requestUser)
+}
+
+func (r *reactor) admit(ctx context.Context, attrs admission.Attributes) error {
+	if mutating, ok := r.admission.(admission.MutationInterface); ok && mutating.Handles(attrs.GetOperation()) {
+		return mutating.Admit(ctx, attrs, objectInterfaces)
+	}
+	return nil
+}
+
+func (r *reactor) validate(ctx context.Context, attrs admission.Attributes) error {
+	if validating, ok := r.admission.(admission.ValidationInterface); ok && validating.Handles(attrs.GetOperation()) {
+		return validating.Validate(ctx, attrs, objectInterfaces)
+	}
+	return nil
+}
+
+var objectInterfaces = admission.NewObjectInterfacesFromScheme(apiserver.Scheme)
+
+// toInternal defaults a copy of a versioned object, like the server does when
+// it decodes a request, and converts it to the internal version.
+func toInternal(obj runtime.Object) (runtime.Object, schema.GroupVersionKind, error) {
+	kinds, _, err := apiserver.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return nil, schema.GroupVersionKind{}, err
+	}
+	versioned := obj.DeepCopyObject()
+	apiserver.Scheme.Default(versioned)
+	internal, err := apiserver.Scheme.ConvertToVersion(versioned, wardle.SchemeGroupVersion)
+	if err != nil {
+		return nil, schema.GroupVersionKind{}, err
+	}
+	return internal, kinds[0], nil
+}
+
+func fromInternal(obj runtime.Object, gv schema.GroupVersion) (runtime.Object, error) {
+	return apiserver.Scheme.ConvertToVersion(obj, gv)
+}
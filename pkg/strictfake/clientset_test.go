@@ -0,0 +1,282 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strictfake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1alpha1"
+	"k8s.io/sample-apiserver/pkg/apis/wardle/v1beta1"
+	wardleapply "k8s.io/sample-apiserver/pkg/generated/applyconfiguration/wardle/v1alpha1"
+)
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	cs, err := NewClientset(nil)
+	require.NoError(t, err)
+	flunders := cs.WardleV1alpha1().Flunders("ns")
+
+	created, err := flunders.Create(ctx, &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "flunder-"},
+		Spec:       v1alpha1.FlunderSpec{Reference: "other"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Name)
+	assert.NotEmpty(t, created.UID)
+	require.NotNil(t, created.Spec.ReferenceType, "reference type must be defaulted")
+	assert.Equal(t, v1alpha1.FlunderReferenceType, *created.Spec.ReferenceType)
+
+	stored, err := flunders.Get(ctx, created.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, created, stored)
+
+	_, err = cs.WardleV1beta1().Flunders("ns").Create(ctx, &v1beta1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+		Spec:       v1beta1.FlunderSpec{ReferenceType: v1beta1.FischerReferenceType, FlunderReference: "other"},
+	}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid, got %v", err)
+	_, err = cs.WardleV1beta1().Flunders("ns").Get(ctx, "invalid", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "invalid flunder must not be stored, got %v", err)
+}
+
+func TestBanFlunder(t *testing.T) {
+	ctx := context.Background()
+	cs, err := NewClientset([]string{"BanFlunder"})
+	require.NoError(t, err)
+
+	_, err = cs.WardleV1alpha1().Flunders("ns").Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "banned"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, cs.WardleV1alpha1().Flunders("ns").Delete(ctx, "banned", metav1.DeleteOptions{}))
+
+	_, err = cs.WardleV1alpha1().Fischers().Create(ctx, &v1alpha1.Fischer{
+		ObjectMeta:         metav1.ObjectMeta{Name: "fischer"},
+		DisallowedFlunders: []string{"banned"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// the fischer is seen immediately, there is no informer to wait for
+	_, err = cs.WardleV1alpha1().Flunders("ns").Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "banned"}}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsForbidden(err), "expected Forbidden, got %v", err)
+	_, err = cs.WardleV1alpha1().Flunders("ns").Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "allowed"}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	// server-side apply of a missing flunder creates it
+	_, err = cs.WardleV1alpha1().Flunders("ns").Apply(ctx, wardleapply.Flunder("banned", "ns"), metav1.ApplyOptions{FieldManager: "test"})
+	assert.True(t, apierrors.IsForbidden(err), "expected Forbidden, got %v", err)
+	_, err = cs.WardleV1alpha1().Flunders("ns").Get(ctx, "banned", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "banned flunder must not be stored, got %v", err)
+	applied, err := cs.WardleV1alpha1().Flunders("ns").Apply(ctx, wardleapply.Flunder("applied", "ns"), metav1.ApplyOptions{FieldManager: "test"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, applied.UID)
+}
+
+func TestFlunderQuota(t *testing.T) {
+	ctx := context.Background()
+	one := *resource.NewQuantity(1, resource.DecimalSI)
+	cs, err := NewClientset([]string{"FlunderQuota"}, &v1alpha1.FlunderQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "ns"},
+		Spec:       v1alpha1.FlunderQuotaSpec{Hard: corev1.ResourceList{v1alpha1.ResourceFlunders: one}},
+		Status: v1alpha1.FlunderQuotaStatus{
+			Hard: corev1.ResourceList{v1alpha1.ResourceFlunders: one},
+			Used: corev1.ResourceList{v1alpha1.ResourceFlunders: *resource.NewQuantity(0, resource.DecimalSI)},
+		},
+	})
+	require.NoError(t, err)
+	flunders := cs.WardleV1alpha1().Flunders("ns")
+
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "first"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	quota, err := cs.WardleV1alpha1().FlunderQuotas("ns").Get(ctx, "quota", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, one.Equal(quota.Status.Used[v1alpha1.ResourceFlunders]), "the flunder must be charged, got %v", quota.Status.Used)
+
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "second"}}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsForbidden(err), "expected Forbidden, got %v", err)
+}
+
+func TestCELValidation(t *testing.T) {
+	ctx := context.Background()
+	cs, err := NewClientsetWithConfig([]string{"CELValidation"}, map[string]string{"CELValidation": `
+rules:
+- kind: Flunder
+  expression: "object.metadata.name.startsWith('team-')"
+  message: flunder names must start with team-
+`})
+	require.NoError(t, err)
+	flunders := cs.WardleV1alpha1().Flunders("ns")
+
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "other"}}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsForbidden(err), "expected Forbidden, got %v", err)
+	assert.ErrorContains(t, err, "flunder names must start with team-")
+}
+
+func TestFlunderDefaults(t *testing.T) {
+	ctx := context.Background()
+	cs, err := NewClientset([]string{"FlunderDefaults"}, &v1alpha1.Fischer{
+		ObjectMeta: metav1.ObjectMeta{Name: "fischer"},
+		FlunderDefaults: []v1alpha1.FlunderDefaults{
+			{NamePattern: "team-*", Labels: map[string]string{"team": "a"}},
+		},
+	})
+	require.NoError(t, err)
+	flunders := cs.WardleV1alpha1().Flunders("ns")
+
+	defaulted, err := flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "team-flunder"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "a"}, defaulted.Labels)
+
+	other, err := flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "other"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, other.Labels)
+}
+
+func TestUnknownAdmissionPlugin(t *testing.T) {
+	_, err := NewClientset([]string{"Unknown"})
+	assert.Error(t, err)
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	cs, err := NewClientset(nil)
+	require.NoError(t, err)
+	flunders := cs.WardleV1beta1().Flunders("ns")
+
+	created, err := flunders.Create(ctx, &v1beta1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "flunder"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ResourceVersion)
+
+	unconditional := created.DeepCopy()
+	unconditional.ResourceVersion = ""
+	_, err = flunders.Update(ctx, unconditional, metav1.UpdateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid, got %v", err)
+
+	immutable := created.DeepCopy()
+	immutable.UID = "other"
+	_, err = flunders.Update(ctx, immutable, metav1.UpdateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid, got %v", err)
+
+	valid := created.DeepCopy()
+	valid.Labels = map[string]string{"updated": "true"}
+	updated, err := flunders.Update(ctx, valid, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "true", updated.Labels["updated"])
+	assert.NotEqual(t, created.ResourceVersion, updated.ResourceVersion)
+
+	_, err = flunders.Update(ctx, valid, metav1.UpdateOptions{})
+	assert.True(t, apierrors.IsConflict(err), "expected Conflict, got %v", err)
+}
+
+func TestPatch(t *testing.T) {
+	ctx := context.Background()
+	cs, err := NewClientset(nil, &v1alpha1.Fischer{ObjectMeta: metav1.ObjectMeta{Name: "fischer", UID: "uid"}})
+	require.NoError(t, err)
+	fischers := cs.WardleV1alpha1().Fischers()
+	watcher, err := fischers.Watch(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	defer watcher.Stop()
+
+	_, err = fischers.Patch(ctx, "fischer", types.MergePatchType, []byte(`{"metadata":{"uid":"other"}}`), metav1.PatchOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid, got %v", err)
+	stored, err := fischers.Get(ctx, "fischer", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, types.UID("uid"), stored.UID, "rejected patch must not be stored")
+
+	patched, err := fischers.Patch(ctx, "fischer", types.MergePatchType, []byte(`{"disallowedFlunders":["banned"]}`), metav1.PatchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"banned"}, patched.DisallowedFlunders)
+	assert.NotEqual(t, stored.ResourceVersion, patched.ResourceVersion)
+
+	// watchers see only the accepted patch
+	event := <-watcher.ResultChan()
+	assert.Equal(t, watch.Modified, event.Type)
+	assert.Equal(t, patched, event.Object)
+	select {
+	case event := <-watcher.ResultChan():
+		t.Errorf("unexpected event %s", event.Type)
+	default:
+	}
+}
+
+func TestOwnership(t *testing.T) {
+	ctx := context.Background()
+	cs, err := NewClientset(nil)
+	require.NoError(t, err)
+	flunders := cs.WardleV1alpha1().Flunders("ns")
+
+	owner, err := flunders.Create(ctx, &v1alpha1.Flunder{ObjectMeta: metav1.ObjectMeta{Name: "owner"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	policy := v1alpha1.OwnedByReferenceOwnershipPolicy
+	owned, err := flunders.Create(ctx, &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "owned"},
+		Spec:       v1alpha1.FlunderSpec{Reference: "owner", OwnershipPolicy: policy},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Len(t, owned.OwnerReferences, 1)
+	assert.Equal(t, owner.UID, owned.OwnerReferences[0].UID)
+
+	_, err = flunders.Create(ctx, &v1alpha1.Flunder{
+		ObjectMeta: metav1.ObjectMeta{Name: "orphan"},
+		Spec:       v1alpha1.FlunderSpec{Reference: "missing", OwnershipPolicy: policy},
+	}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid, got %v", err)
+}
+
+func TestDuplicateDisallowedFlunders(t *testing.T) {
+	ctx := context.Background()
+	cs, err := NewClientset(nil)
+	require.NoError(t, err)
+
+	_, err = cs.WardleV1alpha1().Fischers().Create(ctx, &v1alpha1.Fischer{
+		ObjectMeta:         metav1.ObjectMeta{Name: "fischer"},
+		DisallowedFlunders: []string{"banned", "banned"},
+	}, metav1.CreateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid, got %v", err)
+}
+
+func TestExistingDuplicateDisallowedFlunders(t *testing.T) {
+	ctx := context.Background()
+	// duplicates were allowed before disallowedFlunders became a set
+	cs, err := NewClientset(nil, &v1alpha1.Fischer{
+		ObjectMeta:         metav1.ObjectMeta{Name: "fischer"},
+		DisallowedFlunders: []string{"banned", "banned"},
+	})
+	require.NoError(t, err)
+	fischers := cs.WardleV1alpha1().Fischers()
+
+	fischer, err := fischers.Get(ctx, "fischer", metav1.GetOptions{})
+	require.NoError(t, err)
+	fischer.Labels = map[string]string{"updated": "true"}
+	fischer, err = fischers.Update(ctx, fischer, metav1.UpdateOptions{})
+	require.NoError(t, err, "existing duplicates must not prevent updates")
+
+	fischer.DisallowedFlunders = append(fischer.DisallowedFlunders, "other", "other")
+	_, err = fischers.Update(ctx, fischer, metav1.UpdateOptions{})
+	assert.True(t, apierrors.IsInvalid(err), "expected Invalid for a new duplicate, got %v", err)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strictfake
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/sample-apiserver/pkg/apiserver"
+	informers "k8s.io/sample-apiserver/pkg/generated/informers/externalversions"
+	"k8s.io/sample-apiserver/pkg/generated/informers/externalversions/internalinterfaces"
+	"k8s.io/sample-apiserver/pkg/generated/informers/externalversions/wardle"
+)
+
+// trackerInformerFactory returns informers which are never started, but whose
+// indexers are filled from the tracker whenever they are read. Started
+// informers of a fake clientset can miss the objects created between their
+// list and watch, which would make admission flaky.
+type trackerInformerFactory struct {
+	informers.SharedInformerFactory
+	tracker testing.ObjectTracker
+}
+
+func newTrackerInformerFactory(f informers.SharedInformerFactory, tracker testing.ObjectTracker) informers.SharedInformerFactory {
+	return &trackerInformerFactory{SharedInformerFactory: f, tracker: tracker}
+}
+
+func (f *trackerInformerFactory) Wardle() wardle.Interface {
+	return wardle.New(f, "", nil)
+}
+
+func (f *trackerInformerFactory) InformerFor(obj runtime.Object, newFunc internalinterfaces.NewInformerFunc) cache.SharedIndexInformer {
+	informer := f.SharedInformerFactory.InformerFor(obj, newFunc)
+	return &trackerInformer{
+		SharedIndexInformer: informer,
+		indexer: &trackerIndexer{
+			Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, informer.GetIndexer().GetIndexers()),
+			tracker: f.tracker,
+			obj:     obj,
+		},
+	}
+}
+
+type trackerInformer struct {
+	cache.SharedIndexInformer
+	indexer *trackerIndexer
+}
+
+func (i *trackerInformer) HasSynced() bool {
+	return true
+}
+
+func (i *trackerInformer) GetIndexer() cache.Indexer {
+	return i.indexer
+}
+
+func (i *trackerInformer) GetStore() cache.Store {
+	return i.indexer
+}
+
+// trackerIndexer replaces its content with the objects of its kind in the
+// tracker before every read.
+type trackerIndexer struct {
+	cache.Indexer
+	tracker testing.ObjectTracker
+	obj     runtime.Object
+
+	lock sync.Mutex
+}
+
+func (i *trackerIndexer) refresh() {
+	kinds, _, err := apiserver.Scheme.ObjectKinds(i.obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to determine the kind of %T: %w", i.obj, err))
+		return
+	}
+	kind := kinds[0]
+	resource, _ := meta.UnsafeGuessKindToResource(kind)
+	list, err := i.tracker.List(resource, kind, "")
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list %s: %w", resource, err))
+		return
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to extract %s: %w", resource, err))
+		return
+	}
+	objs := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		objs = append(objs, item)
+	}
+	if err := i.Indexer.Replace(objs, ""); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to index %s: %w", resource, err))
+	}
+}
+
+func (i *trackerIndexer) List() []interface{} {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.refresh()
+	return i.Indexer.List()
+}
+
+func (i *trackerIndexer) ListKeys() []string {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.refresh()
+	return i.Indexer.ListKeys()
+}
+
+func (i *trackerIndexer) Get(obj interface{}) (interface{}, bool, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.refresh()
+	return i.Indexer.Get(obj)
+}
+
+func (i *trackerIndexer) GetByKey(key string) (interface{}, bool, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.refresh()
+	return i.Indexer.GetByKey(key)
+}
+
+func (i *trackerIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.refresh()
+	return i.Indexer.Index(indexName, obj)
+}
+
+func (i *trackerIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.refresh()
+	return i.Indexer.ByIndex(indexName, indexedValue)
+}